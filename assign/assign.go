@@ -0,0 +1,164 @@
+// Package assign provides solvers for matching workers (e.g. ships) to tasks
+// (e.g. planets) at minimal total cost.
+package assign
+
+import (
+	"math"
+)
+
+// Unassigned marks a worker which was not matched to any task.
+const Unassigned = -1
+
+// CostFunc returns the cost of assigning worker "w" to task "t". A cost of
+// positive infinity forbids the pairing.
+type CostFunc func(w, t int) float64
+
+// Solve returns the globally optimal assignment of workers to tasks using the
+// Hungarian method. Task "t" accepts up to caps[t] workers. The returned slice
+// holds the assigned task index for each worker, or Unassigned. As many
+// workers as possible are assigned before cost is considered.
+func Solve(workers int, caps []int, cost CostFunc) []int {
+	res := make([]int, workers)
+	for i := range res {
+		res[i] = Unassigned
+	}
+
+	var slots []int
+	for t, c := range caps {
+		for i := 0; i < c; i++ {
+			slots = append(slots, t)
+		}
+	}
+
+	if workers == 0 || len(slots) == 0 {
+		return res
+	}
+
+	cs := make([][]float64, workers)
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for w := range cs {
+		cs[w] = make([]float64, len(caps))
+		for t := range caps {
+			c := cost(w, t)
+			cs[w][t] = c
+
+			if math.IsInf(c, 1) {
+				continue
+			}
+			lo, hi = math.Min(lo, c), math.Max(hi, c)
+		}
+	}
+
+	if math.IsInf(lo, 1) {
+		return res
+	}
+
+	// leaving a worker unassigned must cost more than any achievable
+	// rearrangement of assigned workers
+	skip := (hi-lo+1)*float64(workers+1) + hi
+	forbid := skip + 1
+
+	m := len(slots) + workers
+	a := make([][]float64, workers)
+	for w := range a {
+		a[w] = make([]float64, m)
+		for j := range a[w] {
+			if j >= len(slots) {
+				a[w][j] = skip
+				continue
+			}
+
+			c := cs[w][slots[j]]
+			if math.IsInf(c, 1) {
+				c = forbid
+			}
+			a[w][j] = c
+		}
+	}
+
+	for w, j := range hungarian(a) {
+		if j >= len(slots) || math.IsInf(cs[w][slots[j]], 1) {
+			continue
+		}
+
+		res[w] = slots[j]
+	}
+
+	return res
+}
+
+// hungarian returns the column assigned to each row of the cost matrix "a"
+// such that the total cost is minimal. The matrix must have no more rows than
+// columns.
+//
+// https://e-maxx.ru/algo/assignment_hungary
+func hungarian(a [][]float64) []int {
+	n, m := len(a), len(a[0])
+
+	u := make([]float64, n+1)
+	v := make([]float64, m+1)
+	p := make([]int, m+1)
+	way := make([]int, m+1)
+	minv := make([]float64, m+1)
+	used := make([]bool, m+1)
+
+	for i := 1; i <= n; i++ {
+		p[0] = i
+		j0 := 0
+
+		for j := range minv {
+			minv[j] = math.Inf(1)
+			used[j] = false
+		}
+
+		for p[j0] != 0 {
+			used[j0] = true
+			i0 := p[j0]
+			delta := math.Inf(1)
+			j1 := 0
+
+			for j := 1; j <= m; j++ {
+				if used[j] {
+					continue
+				}
+
+				cur := a[i0-1][j-1] - u[i0] - v[j]
+				if cur < minv[j] {
+					minv[j] = cur
+					way[j] = j0
+				}
+				if minv[j] < delta {
+					delta = minv[j]
+					j1 = j
+				}
+			}
+
+			for j := 0; j <= m; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+					continue
+				}
+
+				minv[j] -= delta
+			}
+
+			j0 = j1
+		}
+
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+
+	res := make([]int, n)
+	for j := 1; j <= m; j++ {
+		if p[j] != 0 {
+			res[p[j]-1] = j - 1
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,81 @@
+package assign
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSolve(t *testing.T) {
+	inf := math.Inf(1)
+
+	ds := []struct {
+		caps []int
+		cs   [][]float64
+		res  []int
+	}{
+		{
+			[]int{1, 1, 1},
+			[][]float64{
+				{4, 1, 3},
+				{2, 0, 5},
+				{3, 2, 2},
+			},
+			[]int{1, 0, 2},
+		},
+		{
+			[]int{2, 1},
+			[][]float64{
+				{1, 9},
+				{1, 9},
+				{1, 2},
+			},
+			[]int{0, 0, 1},
+		},
+		{
+			[]int{1},
+			[][]float64{
+				{5},
+				{1},
+				{3},
+			},
+			[]int{Unassigned, 0, Unassigned},
+		},
+		{
+			[]int{1, 1},
+			[][]float64{
+				{1, inf},
+				{2, inf},
+			},
+			[]int{0, Unassigned},
+		},
+		{
+			[]int{1, 1},
+			[][]float64{
+				{1, 100},
+				{2, inf},
+			},
+			[]int{1, 0},
+		},
+		{
+			[]int{0, 3},
+			[][]float64{
+				{1, 4},
+				{1, 6},
+			},
+			[]int{1, 1},
+		},
+	}
+
+	for _, d := range ds {
+		got := Solve(len(d.cs), d.caps, func(w, t int) float64 {
+			return d.cs[w][t]
+		})
+
+		for i := range d.res {
+			if got[i] != d.res[i] {
+				t.Errorf("got %v, want %v - caps %v, costs %v", got, d.res, d.caps, d.cs)
+				break
+			}
+		}
+	}
+}
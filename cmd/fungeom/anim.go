@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"os"
+	"path/filepath"
+
+	"github.com/daved/halitego/ops"
+	"github.com/llgcode/draw2d/draw2dimg"
+)
+
+// animation describes how a range of turns is rendered.
+type animation struct {
+	from, to  int
+	scale     float64
+	trailLen  int
+	delay     int
+	gifOut    string
+	framesDir string
+}
+
+// renderAnimation draws each turn in range to an animated GIF and/or a
+// numbered PNG sequence. "decide" returns the overlaid commands of a turn
+// and may be nil.
+func renderAnimation(g *game, player int, a animation, decide func(turn int) ops.CommandMessengers) (err error) {
+	if a.to < 0 || a.to >= len(g.boards) {
+		a.to = len(g.boards) - 1
+	}
+	if a.from < 0 || a.from > a.to {
+		return fmt.Errorf("bad turn range %d-%d", a.from, a.to)
+	}
+
+	if a.framesDir != "" {
+		if err := os.MkdirAll(a.framesDir, 0755); err != nil {
+			return err
+		}
+	}
+
+	h := newHistory(a.trailLen)
+	anim := &gif.GIF{}
+
+	for k := a.from; k <= a.to; k++ {
+		var ms ops.CommandMessengers
+		if decide != nil {
+			ms = decide(k)
+		}
+
+		c := newGraphicContext(g.w, g.h, a.scale)
+		c.addScene(h.apply(makeScene(g.boards[k], player, ms)))
+		img := c.render()
+
+		if a.framesDir != "" {
+			fn := filepath.Join(a.framesDir, fmt.Sprintf("frame_%04d.png", k))
+			if err := draw2dimg.SaveToPngFile(fn, img); err != nil {
+				return err
+			}
+		}
+
+		if a.gifOut != "" {
+			anim.Image = append(anim.Image, paletted(img))
+			anim.Delay = append(anim.Delay, a.delay)
+		}
+	}
+
+	if a.gifOut == "" {
+		return nil
+	}
+
+	f, err := os.Create(a.gifOut)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	return gif.EncodeAll(f, anim)
+}
+
+func paletted(img image.Image) *image.Paletted {
+	p := image.NewPaletted(img.Bounds(), palette.Plan9)
+	draw.Draw(p, p.Rect, img, img.Bounds().Min, draw.Src)
+
+	return p
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"image"
+	icolor "image/color"
+	"image/draw"
+
+	"github.com/daved/halitego/ops"
+	"github.com/llgcode/draw2d/draw2dimg"
+	"github.com/llgcode/draw2d/draw2dkit"
+)
+
+var (
+	opaq = uint8(0xFF)
+	tran = uint8(0x60)
+	redo = icolor.RGBA{0xFF, 0x00, 0x00, opaq}
+	redt = altAlpha(redo, tran)
+	puro = icolor.RGBA{0xFF, 0x00, 0x99, opaq}
+	bluo = icolor.RGBA{0x00, 0x00, 0xFF, opaq}
+	orao = icolor.RGBA{0xFF, 0xA5, 0x00, opaq}
+	yelo = icolor.RGBA{0xFF, 0xFF, 0x00, opaq}
+	yelt = altAlpha(yelo, tran)
+	grno = icolor.RGBA{0x00, 0xC0, 0x00, opaq}
+	grao = icolor.RGBA{0x80, 0x80, 0x80, opaq}
+	whio = icolor.RGBA{0xFF, 0xFF, 0xFF, opaq}
+	blko = icolor.RGBA{0x10, 0x10, 0x18, opaq}
+
+	playerColors = []icolor.RGBA{bluo, redo, grno, orao}
+
+	lMd = 0.25
+)
+
+func altAlpha(rgba icolor.RGBA, alpha uint8) icolor.RGBA {
+	rgba.A = alpha
+	return rgba
+}
+
+func ownerColor(owner int) icolor.RGBA {
+	if owner < 0 {
+		return grao
+	}
+
+	return playerColors[owner%len(playerColors)]
+}
+
+type drawer interface {
+	draw(*draw2dimg.GraphicContext)
+}
+
+type graphicContext struct {
+	dest *image.RGBA
+	*draw2dimg.GraphicContext
+	drawers []drawer
+}
+
+func newGraphicContext(x, y int, scale float64) *graphicContext {
+	dest := image.NewRGBA(image.Rect(0, 0, int(float64(x)*scale), int(float64(y)*scale)))
+	draw.Draw(dest, dest.Bounds(), image.NewUniform(blko), image.Point{}, draw.Src)
+
+	c := &graphicContext{
+		dest:           dest,
+		GraphicContext: draw2dimg.NewGraphicContext(dest),
+	}
+
+	c.Scale(scale, scale)
+	c.SetLineWidth(lMd)
+
+	return c
+}
+
+func (c *graphicContext) render() *image.RGBA {
+	for _, v := range c.drawers {
+		v.draw(c.GraphicContext)
+	}
+
+	return c.dest
+}
+
+func (c *graphicContext) save(filename string) error {
+	return draw2dimg.SaveToPngFile(filename, c.render())
+}
+
+func (c *graphicContext) addDrawers(ds ...drawer) {
+	c.drawers = append(c.drawers, ds...)
+}
+
+// addScene adds drawers for each layer of the scene, from bottom to top:
+// ship trails, path polygons, planets, ships, and thrust vectors.
+func (c *graphicContext) addScene(sc scene) {
+	for _, t := range sc.trails {
+		c.addDrawers(makePolyline(t.cs, altAlpha(ownerColor(t.owner), tran)))
+	}
+
+	for _, p := range sc.paths {
+		color := yelt
+		if p.blocked {
+			color = redt
+		}
+
+		c.addDrawers(makePoly(p.poly, color))
+	}
+
+	for _, p := range sc.planets {
+		stroke := icolor.Color(whio)
+		if p.changed {
+			stroke = yelo
+		}
+
+		c.addDrawers(makeEntity(p.x, p.y, p.r, altAlpha(ownerColor(p.owner), 0xB0), stroke))
+	}
+
+	for _, s := range sc.ships {
+		stroke := ownerColor(s.owner)
+		if s.status != ops.Undocked {
+			stroke = yelo
+		}
+
+		c.addDrawers(makeEntity(s.x, s.y, s.r, ownerColor(s.owner), stroke))
+	}
+
+	for _, p := range sc.paths {
+		c.addDrawers(makeLine(p.from, p.to, whio))
+	}
+}
+
+type entity struct {
+	x, y, r float64
+	fill    icolor.Color
+	stroke  icolor.Color
+}
+
+func makeEntity(x, y, radius float64, fill, stroke icolor.Color) entity {
+	return entity{
+		x:      x,
+		y:      y,
+		r:      radius,
+		fill:   fill,
+		stroke: stroke,
+	}
+}
+
+func (e entity) draw(gctx *draw2dimg.GraphicContext) {
+	gctx.SetFillColor(e.fill)
+	gctx.SetStrokeColor(e.stroke)
+
+	draw2dkit.Circle(gctx, e.x, e.y, e.r)
+	gctx.FillStroke()
+}
+
+type line struct {
+	a, b coord
+	icolor.Color
+}
+
+func makeLine(a, b coord, color icolor.Color) line {
+	return line{
+		a:     a,
+		b:     b,
+		Color: color,
+	}
+}
+
+func (l line) draw(gctx *draw2dimg.GraphicContext) {
+	gctx.SetStrokeColor(l.Color)
+
+	gctx.MoveTo(l.a.x, l.a.y)
+	gctx.LineTo(l.b.x, l.b.y)
+	gctx.Stroke()
+}
+
+type polyline struct {
+	cs []coord
+	icolor.Color
+}
+
+func makePolyline(cs []coord, color icolor.Color) polyline {
+	return polyline{
+		cs:    cs,
+		Color: color,
+	}
+}
+
+func (p polyline) draw(gctx *draw2dimg.GraphicContext) {
+	gctx.SetStrokeColor(p.Color)
+
+	if len(p.cs) < 2 {
+		return
+	}
+
+	gctx.MoveTo(p.cs[0].x, p.cs[0].y)
+	for _, v := range p.cs[1:] {
+		gctx.LineTo(v.x, v.y)
+	}
+
+	gctx.Stroke()
+}
+
+type poly struct {
+	cs []coord
+	icolor.Color
+}
+
+func makePoly(cs []coord, color icolor.Color) poly {
+	return poly{
+		cs:    cs,
+		Color: color,
+	}
+}
+
+func (p poly) draw(gctx *draw2dimg.GraphicContext) {
+	gctx.SetFillColor(p.Color)
+	gctx.SetStrokeColor(p.Color)
+
+	if len(p.cs) < 3 {
+		return
+	}
+
+	gctx.MoveTo(p.cs[0].x, p.cs[0].y)
+	for _, v := range p.cs[1:] {
+		gctx.LineTo(v.x, v.y)
+	}
+	gctx.Close()
+
+	gctx.FillStroke()
+}
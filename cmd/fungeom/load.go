@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/daved/halitego/ops"
+)
+
+// game holds the boards of a recorded game in turn order. The first board
+// is the initial board.
+type game struct {
+	w, h   int
+	player int
+	boards []ops.Board
+}
+
+// loadGame reads either a wire recording (the lines sent by the engine to a
+// bot) or an uncompressed replay file.
+func loadGame(filename string) (*game, error) {
+	bs, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(bs), []byte("{")) {
+		return loadReplay(bs)
+	}
+
+	return loadWire(bs)
+}
+
+// loadWire parses the player ID, map dimensions, and one board per line.
+// Recordings made by ops.WithRecorder are also accepted, in which case only
+// the received lines are used.
+func loadWire(bs []byte) (*game, error) {
+	var ls []string
+	sc := bufio.NewScanner(bytes.NewReader(bs))
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for sc.Scan() {
+		l := sc.Text()
+		if strings.HasPrefix(l, "> ") {
+			continue
+		}
+
+		if l = strings.TrimSpace(strings.TrimPrefix(l, "< ")); l != "" {
+			ls = append(ls, l)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(ls) < 3 {
+		return nil, fmt.Errorf("wire recording requires a player ID, dimensions, and at least one board")
+	}
+
+	id, err := strconv.Atoi(ls[0])
+	if err != nil {
+		return nil, fmt.Errorf("bad player ID: %v", err)
+	}
+
+	var w, h int
+	if _, err := fmt.Sscanf(ls[1], "%d %d", &w, &h); err != nil {
+		return nil, fmt.Errorf("bad dimensions: %v", err)
+	}
+
+	g := &game{w: w, h: h, player: id}
+	for k, l := range ls[2:] {
+		b, err := ops.ParseBoard(w, h, l)
+		if err != nil {
+			return nil, fmt.Errorf("turn %d: %v", k, err)
+		}
+
+		g.boards = append(g.boards, b)
+	}
+
+	return g, nil
+}
+
+type replay struct {
+	Width      int `json:"width"`
+	Height     int `json:"height"`
+	NumPlayers int `json:"num_players"`
+	Planets    []struct {
+		ID           int     `json:"id"`
+		X            float64 `json:"x"`
+		Y            float64 `json:"y"`
+		R            float64 `json:"r"`
+		DockingSpots int     `json:"docking_spots"`
+	} `json:"planets"`
+	Frames []struct {
+		Planets map[string]struct {
+			Health        float64 `json:"health"`
+			DockedShips   []int   `json:"docked_ships"`
+			Owner         *int    `json:"owner"`
+			RemainingProd float64 `json:"remaining_production"`
+			CurrentProd   float64 `json:"current_production"`
+		} `json:"planets"`
+		Ships map[string]map[string]struct {
+			ID       int     `json:"id"`
+			X        float64 `json:"x"`
+			Y        float64 `json:"y"`
+			Health   float64 `json:"health"`
+			VelX     float64 `json:"vel_x"`
+			VelY     float64 `json:"vel_y"`
+			Cooldown float64 `json:"cooldown"`
+			Docking  struct {
+				Status   string  `json:"status"`
+				PlanetID int     `json:"planet_id"`
+				Turns    float64 `json:"turns_left"`
+			} `json:"docking"`
+		} `json:"ships"`
+	} `json:"frames"`
+}
+
+var dockingStatuses = map[string]int{
+	"undocked":  0,
+	"docking":   1,
+	"docked":    2,
+	"undocking": 3,
+}
+
+// loadReplay converts each replay frame into the wire format and parses it
+// so that boards match those seen by bots.
+func loadReplay(bs []byte) (*game, error) {
+	var r replay
+	if err := json.Unmarshal(bs, &r); err != nil {
+		return nil, fmt.Errorf("bad replay: %v", err)
+	}
+
+	g := &game{w: r.Width, h: r.Height}
+
+	for k, f := range r.Frames {
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "%d", r.NumPlayers)
+
+		for p := 0; p < r.NumPlayers; p++ {
+			ss := f.Ships[strconv.Itoa(p)]
+
+			var ids []int
+			for _, s := range ss {
+				ids = append(ids, s.ID)
+			}
+			sort.Ints(ids)
+
+			fmt.Fprintf(&sb, " %d %d", p, len(ids))
+			for _, id := range ids {
+				s := ss[strconv.Itoa(id)]
+				fmt.Fprintf(&sb, " %d %g %g %g %g %g %d %d %g %g",
+					s.ID, s.X, s.Y, s.Health, s.VelX, s.VelY,
+					dockingStatuses[s.Docking.Status], s.Docking.PlanetID,
+					s.Docking.Turns, s.Cooldown)
+			}
+		}
+
+		var pls []string
+		for _, p := range r.Planets {
+			fp, ok := f.Planets[strconv.Itoa(p.ID)]
+			if !ok {
+				continue
+			}
+
+			owned, owner := 0, 0
+			if fp.Owner != nil {
+				owned, owner = 1, *fp.Owner
+			}
+
+			pl := fmt.Sprintf("%d %g %g %g %g %d %g %g %d %d %d",
+				p.ID, p.X, p.Y, fp.Health, p.R, p.DockingSpots,
+				fp.CurrentProd, fp.RemainingProd, owned, owner, len(fp.DockedShips))
+			for _, id := range fp.DockedShips {
+				pl += " " + strconv.Itoa(id)
+			}
+
+			pls = append(pls, pl)
+		}
+
+		fmt.Fprintf(&sb, " %d", len(pls))
+		for _, pl := range pls {
+			sb.WriteString(" " + pl)
+		}
+
+		b, err := ops.ParseBoard(r.Width, r.Height, sb.String())
+		if err != nil {
+			return nil, fmt.Errorf("frame %d: %v", k, err)
+		}
+
+		g.boards = append(g.boards, b)
+	}
+
+	if len(g.boards) == 0 {
+		return nil, fmt.Errorf("replay has no frames")
+	}
+
+	return g, nil
+}
+
+// board returns the board of the provided turn.
+func (g *game) board(turn int) (ops.Board, error) {
+	if turn < 0 || turn >= len(g.boards) {
+		return ops.Board{}, fmt.Errorf("turn %d out of range (0-%d)", turn, len(g.boards)-1)
+	}
+
+	return g.boards[turn], nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/daved/halitego/ops"
+	"github.com/daved/halitego/ops/opstest"
+)
+
+const replayFrame = `{
+	"width": 240, "height": 160, "num_players": 2,
+	"planets": [
+		{"id": 0, "x": 50, "y": 50, "r": 5, "docking_spots": 3},
+		{"id": 1, "x": 150, "y": 100, "r": 8, "docking_spots": 3}
+	],
+	"frames": [{
+		"planets": {
+			"0": {"health": 2000, "docked_ships": [1], "owner": 0, "remaining_production": 1000, "current_production": 0},
+			"1": {"health": 2000, "docked_ships": [3], "owner": 1, "remaining_production": 1000, "current_production": 0}
+		},
+		"ships": {
+			"0": {
+				"1": {"id": 1, "x": 48, "y": 52, "health": 255, "vel_x": 0, "vel_y": 0, "cooldown": 0,
+					"docking": {"status": "docked", "planet_id": 0, "turns_left": 0}},
+				"0": {"id": 0, "x": 10, "y": 20, "health": 255, "vel_x": 0, "vel_y": 0, "cooldown": 0,
+					"docking": {"status": "undocked"}}
+			},
+			"1": {
+				"2": {"id": 2, "x": 200, "y": 140, "health": 128, "vel_x": 0, "vel_y": 0, "cooldown": 1,
+					"docking": {"status": "undocked"}},
+				"3": {"id": 3, "x": 150, "y": 90, "health": 255, "vel_x": 0, "vel_y": 0, "cooldown": 0,
+					"docking": {"status": "docking", "planet_id": 1, "turns_left": 0}}
+			}
+		}
+	}]
+}`
+
+func TestLoadReplay(t *testing.T) {
+	bb := opstest.NewBoard(240, 160, 2).
+		Planet(50, 50, 5, opstest.PlanetPorts(3)).
+		Planet(150, 100, 8).
+		Ship(0, 10, 20).
+		Ship(0, 48, 52, opstest.DockedOn(0)).
+		Ship(1, 200, 140, opstest.ShipHealth(128), opstest.ShipCooldown(1)).
+		Ship(1, 150, 90, opstest.ShipStatus(ops.Docking, 1))
+
+	x, y := bb.Dimensions()
+	wire, err := loadWire([]byte(fmt.Sprintf("0\n%d %d\n%s\n", x, y, bb.Line())))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := loadReplay([]byte(replayFrame))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if g.w != wire.w || g.h != wire.h || len(g.boards) != 1 {
+		t.Fatalf("want a 240x160 game of 1 board, got %dx%d of %d", g.w, g.h, len(g.boards))
+	}
+
+	if !reflect.DeepEqual(g.boards[0], wire.boards[0]) {
+		t.Errorf("want the replay frame to match the wire line\ngot:  %+v\nwant: %+v", g.boards[0], wire.boards[0])
+	}
+}
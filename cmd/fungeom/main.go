@@ -1,254 +1,148 @@
+// Command fungeom renders recorded boards along with the paths and thrusts
+// chosen by a bot, either as a single image or as an animation.
 package main
 
 import (
-	"image"
-	icolor "image/color"
-	"math/rand"
-	"time"
-
-	"github.com/daved/halitego/geom"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	_ "github.com/daved/halitego/internal/bot/hyena"
+	_ "github.com/daved/halitego/internal/bot/lemming"
 	"github.com/daved/halitego/ops"
-	"github.com/llgcode/draw2d/draw2dimg"
-	"github.com/llgcode/draw2d/draw2dkit"
-)
-
-var (
-	opaq = uint8(0xFF)
-	tran = uint8(0x60)
-	redo = icolor.RGBA{0xFF, 0x00, 0x00, opaq}
-	redt = altAlpha(redo, tran)
-	puro = icolor.RGBA{0xFF, 0x00, 0x99, opaq}
-	purt = altAlpha(puro, tran)
-	bluo = icolor.RGBA{0x00, 0x00, 0xFF, opaq}
-	blut = altAlpha(bluo, tran)
-	orao = icolor.RGBA{0xFF, 0xA5, 0x00, opaq}
-	orat = altAlpha(orao, tran)
-	yelo = icolor.RGBA{0xFF, 0xFF, 0x00, opaq}
-	yelt = altAlpha(yelo, tran)
-
-	_, _, _, _, _, _, _, _, _, _ = redo, redt, puro, purt, bluo, blut, orao, orat, yelo, yelt
-
-	pSm, pMd, pLg = 15.0, 20.0, 25.0
-	sMd           = 2.0
-	lMd           = 2.0
-
-	rng = rand.New(rand.NewSource(time.Now().Unix()))
-
-	xaxis, yaxis = 0, 1
-	_, _         = xaxis, yaxis
+	"github.com/daved/halitego/registry"
 )
 
-func altAlpha(rgba icolor.RGBA, alpha uint8) icolor.RGBA {
-	rgba.A = alpha
-	return rgba
-}
+// defaultOut is the png written when no output is requested.
+const defaultOut = "out.png"
 
 func main() {
-	c := newGraphicContext(400, 300)
-
-	destPlanet := makeEntity(100, 250, pSm, puro)
-	planets := []entity{
-		makeEntity(100, 40, pSm, redo),
-		makeEntity(200, 80, pMd, redo),
-		makeEntity(150, 120, pLg, redo),
-		makeEntity(175, 200, pLg, redo),
-		makeEntity(340, 240, pSm, redo),
-		makeEntity(240, 240, pSm, redo),
-		makeEntity(40, 260, pMd, redo),
-		destPlanet,
-	}
-	c.addDrawers(entsToDrawers(planets)...)
-
-	ship := makeEntity(175, 173, sMd, bluo)
-	c.addDrawers(ship)
-
-	dest := geom.BufferedLocation(10, destPlanet, ship)
-	destMarker := makeEntityFromMarker(dest, yelo)
-	c.addDrawers(destMarker)
-
-	var adj func(int, []entity, geom.Marker, geom.Marker)
-	adj = func(count int, ents []entity, t, s geom.Marker) {
-		count++
-		if count > 256 {
-			return
-		}
-
-		ob := geom.Obstacles(entsToMarkers(ents), t, s)
-		if !ob {
-			return
-		}
-
-		buf := float64(rng.Intn(24) + 24)
-		dir := geom.Left
-		if time.Now().Nanosecond()%2 == 0 {
-			dir = geom.Right
-		}
-
-		t = geom.PerpindicularLocation(buf, dir, t, ship)
-		destMarker = makeEntityFromMarker(t, orao)
-		c.addDrawers(destMarker)
-
-		adj(count, ents, t, s)
+	var (
+		in     = ""
+		out    = ""
+		svg    = ""
+		turn   = 0
+		scale  = 4.0
+		bot    = ""
+		player = -1
+		seed   = int64(0)
+		a      = animation{to: -1, trailLen: 8, delay: 10}
+	)
+
+	flag.StringVar(&in, "in", in, "wire recording or uncompressed replay file")
+	flag.StringVar(&out, "out", out, "png file to write (defaults to "+defaultOut+" unless -svg is set)")
+	flag.StringVar(&svg, "svg", svg, "svg file to write")
+	flag.IntVar(&turn, "turn", turn, "turn to render (0 is the initial board)")
+	flag.Float64Var(&scale, "scale", scale, "pixels per board unit")
+	flag.StringVar(&bot, "bot", bot, "bot whose decisions are overlaid")
+	flag.IntVar(&player, "player", player, "player commanded by the bot (defaults to the recorded player)")
+	flag.Int64Var(&seed, "seed", seed, "seed of the bot's randomness")
+	flag.StringVar(&a.gifOut, "gif", a.gifOut, "animated gif of all turns to write")
+	flag.StringVar(&a.framesDir, "frames", a.framesDir, "directory of numbered png frames to write")
+	flag.IntVar(&a.from, "from", a.from, "first animated turn")
+	flag.IntVar(&a.to, "to", a.to, "last animated turn (defaults to the final turn)")
+	flag.IntVar(&a.trailLen, "trail", a.trailLen, "number of positions in animated ship trails")
+	flag.IntVar(&a.delay, "delay", a.delay, "delay between gif frames in 100ths of a second")
+	flag.Parse()
+
+	a.scale = scale
+
+	if err := run(in, out, svg, turn, bot, player, seed, a); err != nil {
+		fmt.Fprintln(os.Stderr, "fungeom:", err)
+		os.Exit(1)
 	}
-
-	adj(0, planets, dest, ship)
-
-	_ = c.save("out.png")
-}
-
-type drawer interface {
-	draw(*draw2dimg.GraphicContext)
 }
 
-type graphicContext struct {
-	dest *image.RGBA
-	*draw2dimg.GraphicContext
-	drawers []drawer
-}
-
-func newGraphicContext(x, y int) *graphicContext {
-	dest := image.NewRGBA(image.Rect(0, 0, x, y))
-
-	c := &graphicContext{
-		dest:           dest,
-		GraphicContext: draw2dimg.NewGraphicContext(dest),
+func run(in, out, svg string, turn int, bot string, player int, seed int64, a animation) error {
+	if in == "" {
+		return fmt.Errorf("an input file is required")
 	}
 
-	c.SetLineWidth(1)
-
-	return c
-}
-
-func (c *graphicContext) save(filename string) error {
-	for _, v := range c.drawers {
-		v.draw(c.GraphicContext)
+	g, err := loadGame(in)
+	if err != nil {
+		return err
 	}
 
-	return draw2dimg.SaveToPngFile(filename, c.dest)
-}
-
-func (c *graphicContext) addDrawers(ents ...drawer) {
-	c.drawers = append(c.drawers, ents...)
-}
-
-type entity struct {
-	ops.Entity
-	icolor.Color
-}
-
-func makeEntity(x, y, radius float64, color icolor.Color) entity {
-	return entity{
-		Entity: ops.MakeEntity(x, y, radius, 0, 0, 0),
-		Color:  color,
+	if player < 0 {
+		player = g.player
 	}
-}
 
-func makeEntityFromMarker(m geom.Marker, color icolor.Color) entity {
-	x, y := m.Coords()
-	r := m.Radius()
-	if r == 0 {
-		r = lMd
+	var decide func(int) ops.CommandMessengers
+	if bot != "" {
+		if decide, err = decider(g, bot, player, seed); err != nil {
+			return err
+		}
 	}
 
-	return makeEntity(x, y, r, color)
-}
-
-func (e entity) draw(gctx *draw2dimg.GraphicContext) {
-	gctx.SetFillColor(e.Color)
-
-	cx, cy := e.Coords()
-	draw2dkit.Circle(gctx, cx, cy, e.Radius())
-	gctx.FillStroke()
-}
-
-func entsToMarkers(ents []entity) []geom.Marker {
-	var ms []geom.Marker
-	for _, v := range ents {
-		ms = append(ms, v)
+	if a.gifOut != "" || a.framesDir != "" {
+		return renderAnimation(g, player, a, decide)
 	}
 
-	return ms
-}
-
-func entsToDrawers(ents []entity) []drawer {
-	var ds []drawer
-	for _, v := range ents {
-		ds = append(ds, v)
+	b, err := g.board(turn)
+	if err != nil {
+		return err
 	}
 
-	return ds
-}
-
-type line struct {
-	a, b float64
-	axis int
-	icolor.Color
-}
-
-func makeLine(a, b float64, axis int, color icolor.Color) line {
-	return line{
-		a:     a,
-		b:     b,
-		axis:  axis,
-		Color: color,
+	var ms ops.CommandMessengers
+	if decide != nil {
+		ms = decide(turn)
 	}
-}
 
-var _ = makeLine(0, 0, 0, blut)
+	sc := makeScene(b, player, ms)
 
-func (l line) draw(gctx *draw2dimg.GraphicContext) {
-	gctx.SetFillColor(l.Color)
-
-	x1, y1 := l.a, 0.0
-	x2, y2 := l.b, 4.0
+	if svg != "" {
+		if err := saveSVG(svg, sc, a.scale, player); err != nil {
+			return err
+		}
 
-	if l.axis == yaxis {
-		x1, y1 = y1, x1
-		x2, y2 = y2, x2
+		if out == "" {
+			return nil
+		}
 	}
 
-	draw2dkit.Rectangle(gctx, x1, y1, x2, y2)
-	gctx.FillStroke()
-}
-
-type coord struct {
-	x, y float64
-}
-
-func makeCoordsFromGeomLocators(ls ...geom.Locator) []coord {
-	var cs []coord
-	for _, v := range ls {
-		x, y := v.Coords()
-		cs = append(cs, coord{x, y})
+	if out == "" {
+		out = defaultOut
 	}
 
-	return cs
-}
+	c := newGraphicContext(g.w, g.h, a.scale)
+	c.addScene(sc)
 
-type poly struct {
-	cs []coord
-	icolor.Color
+	return c.save(out)
 }
 
-func makePoly(cs []coord, color icolor.Color) poly {
-	return poly{
-		cs:    cs,
-		Color: color,
+// decider returns a function which provides the named bot's commands for a
+// turn. The bot is run through every earlier turn first so that any state it
+// keeps matches that of a live game. Bots are not commanded on the initial
+// board, so turn 0 has no commands. Turns must be requested in ascending
+// order.
+func decider(g *game, bot string, player int, seed int64) (func(int) ops.CommandMessengers, error) {
+	_, newBot, err := registry.Lookup(bot)
+	if err != nil {
+		return nil, err
 	}
-}
 
-func (p poly) draw(gctx *draw2dimg.GraphicContext) {
-	gctx.SetFillColor(p.Color)
+	c := newBot(registry.Config{
+		Logger:       log.New(ioutil.Discard, "", 0),
+		InitialBoard: g.boards[0],
+		Seed:         seed,
+	})
 
-	if len(p.cs) < 3 {
-		return
-	}
+	var (
+		last int
+		ms   ops.CommandMessengers
+	)
 
-	gctx.MoveTo(p.cs[0].x, p.cs[0].y)
-	for _, v := range p.cs[1:] {
-		gctx.LineTo(v.x, v.y)
-	}
-	gctx.Close()
+	return func(turn int) ops.CommandMessengers {
+		if turn < last {
+			return nil
+		}
+
+		for ; last < turn; last++ {
+			ms = c.Command(g.boards[last+1], player)
+		}
 
-	gctx.FillStroke()
+		return ms
+	}, nil
 }
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/daved/halitego/geom"
+	"github.com/daved/halitego/ops"
+)
+
+// unowned marks planets which are not owned by any player.
+const unowned = -1
+
+type coord struct {
+	x, y float64
+}
+
+func makeCoordsFromGeomLocators(ls ...geom.Locator) []coord {
+	var cs []coord
+	for _, v := range ls {
+		x, y := v.Coords()
+		cs = append(cs, coord{x, y})
+	}
+
+	return cs
+}
+
+// mark describes a planet or ship to be drawn.
+type mark struct {
+	id      int
+	owner   int
+	x, y    float64
+	r       float64
+	health  float64
+	status  ops.ShipDockingStatus
+	changed bool
+}
+
+// trail describes the recent positions of a ship.
+type trail struct {
+	owner int
+	cs    []coord
+}
+
+// path describes a ship's planned thrust and the area it sweeps.
+type path struct {
+	shipID   int
+	from     coord
+	to       coord
+	poly     []coord
+	blocked  bool
+	blockers []string
+}
+
+// scene holds everything drawn for a single turn.
+type scene struct {
+	w, h    float64
+	planets []mark
+	ships   []mark
+	paths   []path
+	trails  []trail
+}
+
+// makeScene describes the board along with the thrusts found in "ms" for the
+// ships of the provided player.
+func makeScene(b ops.Board, player int, ms ops.CommandMessengers) scene {
+	w, h := b.Dimensions()
+	sc := scene{w: float64(w), h: float64(h)}
+
+	for _, p := range b.Planets() {
+		owner := unowned
+		if p.Owned() {
+			owner = p.Owner()
+		}
+
+		x, y := p.Coords()
+		sc.planets = append(sc.planets, mark{
+			id: p.ID(), owner: owner, x: x, y: y, r: p.Radius(), health: p.Health(),
+		})
+	}
+
+	ships := make(map[int]ops.Ship)
+	for _, g := range b.Ships() {
+		for _, s := range g {
+			x, y := s.Coords()
+			sc.ships = append(sc.ships, mark{
+				id: s.ID(), owner: s.Owner(), x: x, y: y, r: s.Radius(),
+				health: s.Health(), status: s.DockingStatus(),
+			})
+
+			if s.Owner() == player {
+				ships[s.ID()] = s
+			}
+		}
+	}
+
+	for _, m := range ms {
+		var id, mag, ang int
+		if _, err := fmt.Sscanf(m.Message(), "t %d %d %d", &id, &mag, &ang); err != nil {
+			continue
+		}
+
+		s, ok := ships[id]
+		if !ok {
+			continue
+		}
+
+		sc.paths = append(sc.paths, makePath(b, s, mag, ang))
+	}
+
+	return sc
+}
+
+func makePath(b ops.Board, s ops.Ship, mag, ang int) path {
+	sx, sy := s.Coords()
+	r := float64(ang) * math.Pi / 180
+	dest := geom.MakeLocation(sx+float64(mag)*math.Cos(r), sy+float64(mag)*math.Sin(r), s.Radius())
+
+	var blockers []string
+	for _, m := range b.Markers() {
+		if o, ok := m.(ops.Ship); ok && o.ID() == s.ID() {
+			continue
+		}
+
+		if geom.Obstacles([]geom.Marker{m}, dest, s) {
+			blockers = append(blockers, markerName(m))
+		}
+	}
+
+	return path{
+		shipID:   s.ID(),
+		from:     coord{sx, sy},
+		to:       makeCoordsFromGeomLocators(dest)[0],
+		poly:     makeCoordsFromGeomLocators(geom.PathPolygon(dest, s)),
+		blocked:  len(blockers) > 0,
+		blockers: blockers,
+	}
+}
+
+func markerName(m geom.Marker) string {
+	switch v := m.(type) {
+	case ops.Planet:
+		return fmt.Sprintf("planet %d", v.ID())
+	case ops.Ship:
+		return fmt.Sprintf("ship %d", v.ID())
+	}
+
+	x, y := m.Coords()
+	return fmt.Sprintf("marker at %.1f,%.1f", x, y)
+}
+
+// history tracks state across consecutive scenes so that ship trails and
+// planet ownership changes are able to be drawn.
+type history struct {
+	n      int
+	pos    map[int][]coord
+	owners map[int]int
+}
+
+func newHistory(trailLen int) *history {
+	return &history{
+		n:      trailLen,
+		pos:    make(map[int][]coord),
+		owners: make(map[int]int),
+	}
+}
+
+// apply records the scene and adds trails and ownership changes to it.
+func (h *history) apply(sc scene) scene {
+	pos := make(map[int][]coord)
+	for _, s := range sc.ships {
+		cs := append(h.pos[s.id], coord{s.x, s.y})
+		if len(cs) > h.n {
+			cs = cs[len(cs)-h.n:]
+		}
+		pos[s.id] = cs
+
+		if len(cs) > 1 {
+			sc.trails = append(sc.trails, trail{owner: s.owner, cs: cs})
+		}
+	}
+	h.pos = pos
+
+	for k, p := range sc.planets {
+		if prev, ok := h.owners[p.id]; ok && prev != p.owner {
+			sc.planets[k].changed = true
+		}
+		h.owners[p.id] = p.owner
+	}
+
+	return sc
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	icolor "image/color"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/daved/halitego/ops"
+)
+
+// threatRange is the distance from an undocked enemy ship within which it is
+// able to attack during the next turn.
+const threatRange = ops.AttackRange
+
+// saveSVG writes the scene as an SVG document with one group per layer.
+func saveSVG(filename string, sc scene, scale float64, player int) (err error) {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	w := bufio.NewWriter(f)
+	writeSVG(w, sc, scale, player)
+
+	return w.Flush()
+}
+
+func writeSVG(w io.Writer, sc scene, scale float64, player int) {
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%g" viewBox="0 0 %g %g">`+"\n",
+		sc.w*scale, sc.h*scale, sc.w, sc.h)
+	fmt.Fprintf(w, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", hex(blko))
+
+	layer(w, "threat-zones", func() {
+		for _, s := range sc.ships {
+			if s.owner == player || s.status != ops.Undocked {
+				continue
+			}
+
+			circle(w, s.x, s.y, s.r+threatRange, altAlpha(ownerColor(s.owner), 0x20), ownerColor(s.owner),
+				fmt.Sprintf("threat from ship %d (player %d)", s.id, s.owner))
+		}
+	})
+
+	layer(w, "obstacle-polygons", func() {
+		for _, p := range sc.paths {
+			color := yelt
+			title := fmt.Sprintf("path of ship %d: clear", p.shipID)
+			if p.blocked {
+				color = redt
+				title = fmt.Sprintf("path of ship %d: blocked by %s", p.shipID, strings.Join(p.blockers, ", "))
+			}
+
+			var pts []string
+			for _, c := range p.poly {
+				pts = append(pts, fmt.Sprintf("%g,%g", c.x, c.y))
+			}
+
+			fmt.Fprintf(w, `<polygon points="%s" fill="%s" fill-opacity="%.2f"><title>%s</title></polygon>`+"\n",
+				strings.Join(pts, " "), hex(color), opacity(color), escape(title))
+		}
+	})
+
+	layer(w, "planets", func() {
+		for _, p := range sc.planets {
+			owner := "unowned"
+			if p.owner != unowned {
+				owner = fmt.Sprintf("player %d", p.owner)
+			}
+
+			circle(w, p.x, p.y, p.r, altAlpha(ownerColor(p.owner), 0xB0), whio,
+				fmt.Sprintf("planet %d (%s) health %g", p.id, owner, p.health))
+		}
+	})
+
+	layer(w, "ships", func() {
+		for _, s := range sc.ships {
+			stroke := ownerColor(s.owner)
+			if s.status != ops.Undocked {
+				stroke = yelo
+			}
+
+			circle(w, s.x, s.y, s.r, ownerColor(s.owner), stroke,
+				fmt.Sprintf("ship %d (player %d) health %g", s.id, s.owner, s.health))
+		}
+	})
+
+	layer(w, "planned-paths", func() {
+		for _, p := range sc.paths {
+			fmt.Fprintf(w, `<line x1="%g" y1="%g" x2="%g" y2="%g" stroke="%s" stroke-width="%g"><title>%s</title></line>`+"\n",
+				p.from.x, p.from.y, p.to.x, p.to.y, hex(whio), lMd, escape(fmt.Sprintf("thrust of ship %d", p.shipID)))
+		}
+	})
+
+	fmt.Fprintln(w, "</svg>")
+}
+
+func layer(w io.Writer, id string, fn func()) {
+	fmt.Fprintf(w, `<g id="%s">`+"\n", id)
+	fn()
+	fmt.Fprintln(w, "</g>")
+}
+
+func circle(w io.Writer, x, y, r float64, fill, stroke icolor.RGBA, title string) {
+	fmt.Fprintf(w, `<circle cx="%g" cy="%g" r="%g" fill="%s" fill-opacity="%.2f" stroke="%s" stroke-width="%g"><title>%s</title></circle>`+"\n",
+		x, y, r, hex(fill), opacity(fill), hex(stroke), lMd, escape(title))
+}
+
+func hex(c icolor.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func opacity(c icolor.RGBA) float64 {
+	return float64(c.A) / 0xFF
+}
+
+func escape(s string) string {
+	var sb strings.Builder
+	_ = xml.EscapeText(&sb, []byte(s))
+
+	return sb.String()
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/daved/halitego/ops"
+	"github.com/daved/halitego/ops/opstest"
+)
+
+func TestWriteSVG(t *testing.T) {
+	b := opstest.NewBoard(240, 160, 2).
+		Planet(60, 20, 5).
+		Ship(0, 50, 20).
+		Ship(1, 100, 100, opstest.ShipHealth(128)).
+		Board()
+
+	m, err := b.Ships()[0][0].Thrust(b, 7, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sc := makeScene(b, 0, ops.CommandMessengers{m})
+	if len(sc.paths) != 1 || !sc.paths[0].blocked {
+		t.Fatalf("want ship 0's path blocked by planet 0, got %+v", sc.paths)
+	}
+	sc.paths[0].blockers = append(sc.paths[0].blockers, `<ship & "co">`)
+
+	var buf bytes.Buffer
+	writeSVG(&buf, sc, 2, 0)
+
+	var layers, titles []string
+	dec := xml.NewDecoder(bytes.NewReader(buf.Bytes()))
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("want well-formed svg, got %v:\n%s", err, buf.String())
+		}
+
+		se, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch se.Name.Local {
+		case "g":
+			for _, a := range se.Attr {
+				if a.Name.Local == "id" {
+					layers = append(layers, a.Value)
+				}
+			}
+		case "title":
+			var s string
+			if err := dec.DecodeElement(&s, &se); err != nil {
+				t.Fatal(err)
+			}
+			titles = append(titles, s)
+		}
+	}
+
+	want := "threat-zones obstacle-polygons planets ships planned-paths"
+	if got := strings.Join(layers, " "); got != want {
+		t.Errorf("want layers %s, got %s", want, got)
+	}
+
+	for _, want := range []string{
+		"threat from ship 1 (player 1)",
+		`path of ship 0: blocked by planet 0, <ship & "co">`,
+		"planet 0 (unowned) health 2000",
+		"ship 0 (player 0) health 255",
+		"ship 1 (player 1) health 128",
+		"thrust of ship 0",
+	} {
+		if !contains(titles, want) {
+			t.Errorf("want title %q, got %q", want, titles)
+		}
+	}
+
+	if !strings.Contains(buf.String(), "&lt;ship &amp; &#34;co&#34;&gt;") {
+		t.Errorf("want blockers escaped, got:\n%s", buf.String())
+	}
+}
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,29 +1,143 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/codemodus/sigmon"
-	"github.com/daved/halitego/internal/bot/hyena"
+	_ "github.com/daved/halitego/internal/bot/hyena"
+	_ "github.com/daved/halitego/internal/bot/lemming"
+	"github.com/daved/halitego/internal/hlog"
 	"github.com/daved/halitego/ops"
+	"github.com/daved/halitego/registry"
 )
 
+// defaultBot is run when no bot is selected by flag or environment.
+const defaultBot = "hyena"
+
+// seedComment prefixes the recording comment which notes the bot's seed.
+const seedComment = "seed"
+
 func main() {
+	var (
+		botName   = envOr("HALITEGO_BOT", defaultBot)
+		list      bool
+		logLevel  = envOr("HALITEGO_LOG_LEVEL", "off")
+		logFormat = envOr("HALITEGO_LOG_FORMAT", "json")
+		logDir    = envOr("HALITEGO_LOG_DIR", ".")
+		record    = envOr("HALITEGO_RECORD", "")
+		replay    = ""
+		maxFails  = envOr("HALITEGO_MAX_FAILURES", "0")
+		workers   = envOr("HALITEGO_WORKERS", "1")
+		seedStr   = envOr("HALITEGO_SEED", "")
+	)
+
+	flag.StringVar(&botName, "bot", botName, "name of the bot to run (env HALITEGO_BOT)")
+	flag.BoolVar(&list, "list", list, "list available bots and exit")
+	flag.StringVar(&logLevel, "log-level", logLevel, "debug, info, warn, error, or off (env HALITEGO_LOG_LEVEL)")
+	flag.StringVar(&logFormat, "log-format", logFormat, "json or text (env HALITEGO_LOG_FORMAT)")
+	flag.StringVar(&logDir, "log-dir", logDir, "directory of per-game log files (env HALITEGO_LOG_DIR)")
+	flag.StringVar(&record, "record", record, "file to record engine traffic to (env HALITEGO_RECORD)")
+	flag.StringVar(&replay, "replay", replay, "recording to replay and verify, then exit")
+	flag.StringVar(&maxFails, "max-failures", maxFails, "consecutive failed turns before quitting, 0 to never quit (env HALITEGO_MAX_FAILURES)")
+	flag.StringVar(&workers, "workers", workers, "ships evaluated concurrently (env HALITEGO_WORKERS)")
+	flag.StringVar(&seedStr, "seed", seedStr, "seed of all bot randomness, random if empty (env HALITEGO_SEED)")
+	flag.Parse()
+
+	if list {
+		for _, n := range registry.Names() {
+			fmt.Println(n)
+		}
+		return
+	}
+
+	name, newBot, err := registry.Lookup(botName)
+	if err != nil {
+		exitOnErr(err)
+	}
+
+	lvl, err := hlog.ParseLevel(logLevel)
+	if err != nil {
+		exitOnErr(err)
+	}
+
+	lfmt, err := hlog.ParseFormat(logFormat)
+	if err != nil {
+		exitOnErr(err)
+	}
+
+	wkrs, err := strconv.Atoi(workers)
+	if err != nil {
+		exitOnErr(fmt.Errorf("bad workers: %v", err))
+	}
+
+	l := hlog.New(ioutil.Discard, lvl, lfmt)
+	mws := ops.WithMiddleware(ops.Timing(l), ops.Validate(l))
+
+	if replay != "" {
+		l.SetOutput(os.Stderr)
+
+		if err := verifyRecording(replay, seedStr, l, newBot, wkrs, mws); err != nil {
+			exitOnErr(err)
+		}
+
+		fmt.Println("recording verified")
+		return
+	}
+
+	seed := time.Now().UnixNano()
+	if seedStr != "" {
+		if seed, err = strconv.ParseInt(seedStr, 10, 64); err != nil {
+			exitOnErr(fmt.Errorf("bad seed: %v", err))
+		}
+	}
+
+	opts := []ops.Option{mws}
+	if n, err := strconv.Atoi(maxFails); err != nil {
+		exitOnErr(fmt.Errorf("bad max failures: %v", err))
+	} else if n > 0 {
+		opts = append(opts, ops.WithFailurePolicy(ops.MaxConsecutiveFailures(n)))
+	}
+	if record != "" {
+		f, err := os.Create(record)
+		if err != nil {
+			exitOnErr(err)
+		}
+		defer func() { _ = f.Close() }()
+
+		fmt.Fprintf(f, "# %s %d\n", seedComment, seed)
+		opts = append(opts, ops.WithRecorder(f))
+	}
+
 	sm := sigmon.New(func(*sigmon.SignalMonitor) {
 		panic("startup interrupted")
 	})
 	sm.Run()
 
-	l := log.New(ioutil.Discard, "", 0)
-	o := ops.New("Hyena")
-	c := hyena.New(l, o.InitialBoard())
+	o := ops.New(name, opts...)
+	c := newBot(registry.Config{
+		Logger:       l,
+		InitialBoard: o.InitialBoard(),
+		Seed:         seed,
+		Workers:      wkrs,
+	})
+
+	if lvl != hlog.LevelOff {
+		ext := "log"
+		if lfmt == hlog.JSON {
+			ext = "jsonl"
+		}
 
-	if false {
-		fn := fmt.Sprintf("%d_%s", o.ID(), "game.log")
+		fn := filepath.Join(logDir, fmt.Sprintf("%d_game.%s", o.ID(), ext))
 		defer setLoggerOutput(l, fn)()
+
+		l.Info("game started", "bot", name, "player", o.ID(), "seed", seed)
 	}
 
 	sm.Set(func(*sigmon.SignalMonitor) {
@@ -34,7 +148,59 @@ func main() {
 	sm.Stop()
 }
 
-func setLoggerOutput(l *log.Logger, filename string) func() {
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+
+	return fallback
+}
+
+func exitOnErr(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
+
+// verifyRecording replays a recording into the bot. Unless a seed is
+// provided, the seed noted in the recording is used.
+func verifyRecording(filename, seedStr string, l *hlog.Logger, newBot registry.Constructor, workers int, opts ...ops.Option) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	rec, err := ops.ReadRecording(f)
+	if err != nil {
+		return err
+	}
+
+	if seedStr == "" {
+		for _, c := range rec.Comments() {
+			if strings.HasPrefix(c, seedComment+" ") {
+				seedStr = strings.TrimPrefix(c, seedComment+" ")
+			}
+		}
+	}
+
+	var seed int64
+	if seedStr != "" {
+		if seed, err = strconv.ParseInt(seedStr, 10, 64); err != nil {
+			return fmt.Errorf("bad seed: %v", err)
+		}
+	}
+
+	return rec.Verify(l, func(b ops.Board) ops.Commander {
+		return newBot(registry.Config{
+			Logger:       l,
+			InitialBoard: b,
+			Seed:         seed,
+			Workers:      workers,
+		})
+	}, opts...)
+}
+
+func setLoggerOutput(l *hlog.Logger, filename string) func() {
 	var (
 		lFlags = os.O_RDWR | os.O_CREATE | os.O_APPEND
 		lPerms = os.FileMode(0664)
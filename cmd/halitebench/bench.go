@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+)
+
+// benchmarker runs "go test" benchmarks.
+type benchmarker struct {
+	bench     string
+	benchtime string
+	count     int
+	pkgs      []string
+}
+
+// runAt benchmarks revision "rev" of the repository at "root", or the
+// working tree when "rev" is empty. Revisions are checked out as git
+// worktrees within a GOPATH layout under "stage".
+func (b benchmarker) runAt(root, stage, rev string) (string, error) {
+	if rev == "" {
+		return b.run(root, gopathEnv())
+	}
+
+	gopath := filepath.Join(stage, rev)
+	dir := filepath.Join(gopath, "src", filepath.FromSlash(modPath))
+
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return "", err
+	}
+
+	if out, err := git(root, "worktree", "add", "--detach", dir, rev); err != nil {
+		return "", fmt.Errorf("cannot check out: %v\n%s", err, out)
+	}
+	defer func() { _, _ = git(root, "worktree", "remove", "--force", dir) }()
+
+	vendor, err := filepath.Abs(filepath.Join(root, "vendor"))
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(vendor); err == nil {
+		if err := os.Symlink(vendor, filepath.Join(dir, "vendor")); err != nil {
+			return "", err
+		}
+	}
+
+	env := append(gopathEnv(), "GOPATH="+gopath+string(os.PathListSeparator)+goEnv("GOPATH"))
+
+	return b.run(dir, env)
+}
+
+// gopathEnv disables module mode, as the repository is built from a GOPATH
+// with dependencies vendored by dep.
+func gopathEnv() []string {
+	return []string{"GO111MODULE=off", "GOFLAGS="}
+}
+
+func (b benchmarker) run(dir string, env []string) (string, error) {
+	args := []string{"test", "-run", "^$", "-bench", b.bench, "-benchmem",
+		"-count", strconv.Itoa(b.count)}
+	if b.benchtime != "" {
+		args = append(args, "-benchtime", b.benchtime)
+	}
+	args = append(args, b.pkgs...)
+
+	var out, stderr bytes.Buffer
+
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%v\n%s%s", err, out.Bytes(), stderr.Bytes())
+	}
+
+	return out.String(), nil
+}
+
+func git(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	return cmd.CombinedOutput()
+}
+
+func goEnv(key string) string {
+	out, err := exec.Command("go", "env", key).Output()
+	if err != nil {
+		return ""
+	}
+
+	return string(bytes.TrimSpace(out))
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// result holds the measurements of each run of a benchmark.
+type result struct {
+	nsOp     []float64
+	bytesOp  []float64
+	allocsOp []float64
+}
+
+// parseResults reads "go test -bench" output keyed by package and
+// benchmark name.
+func parseResults(out string) map[string]*result {
+	rs := make(map[string]*result)
+	pkg := ""
+
+	sc := bufio.NewScanner(strings.NewReader(out))
+	for sc.Scan() {
+		fs := strings.Fields(sc.Text())
+
+		if len(fs) == 2 && fs[0] == "pkg:" {
+			pkg = fs[1]
+			continue
+		}
+
+		if len(fs) < 4 || !strings.HasPrefix(fs[0], "Benchmark") {
+			continue
+		}
+
+		name := strings.TrimPrefix(pkg+"."+fs[0], modPath+"/")
+		r, ok := rs[name]
+		if !ok {
+			r = &result{}
+			rs[name] = r
+		}
+
+		for i := 2; i+1 < len(fs); i += 2 {
+			v, err := strconv.ParseFloat(fs[i], 64)
+			if err != nil {
+				continue
+			}
+
+			switch fs[i+1] {
+			case "ns/op":
+				r.nsOp = append(r.nsOp, v)
+			case "B/op":
+				r.bytesOp = append(r.bytesOp, v)
+			case "allocs/op":
+				r.allocsOp = append(r.allocsOp, v)
+			}
+		}
+	}
+
+	return rs
+}
+
+// comparison holds the median measurements of a benchmark at both
+// revisions.
+type comparison struct {
+	name                 string
+	oldNs, newNs         float64
+	oldB, newB           float64
+	oldAllocs, newAllocs float64
+}
+
+func (c comparison) delta() float64 {
+	if c.oldNs == 0 {
+		return 0
+	}
+
+	return (c.newNs - c.oldNs) / c.oldNs * 100
+}
+
+func (c comparison) regressed(threshold float64) bool {
+	return c.oldNs > 0 && c.delta() > threshold
+}
+
+// compare pairs benchmarks present at both revisions.
+func compare(old, cur map[string]*result) []comparison {
+	var cs []comparison
+	for name, o := range old {
+		n, ok := cur[name]
+		if !ok {
+			continue
+		}
+
+		cs = append(cs, comparison{
+			name:      name,
+			oldNs:     median(o.nsOp),
+			newNs:     median(n.nsOp),
+			oldB:      median(o.bytesOp),
+			newB:      median(n.bytesOp),
+			oldAllocs: median(o.allocsOp),
+			newAllocs: median(n.allocsOp),
+		})
+	}
+
+	sort.Slice(cs, func(i, j int) bool { return cs[i].name < cs[j].name })
+
+	return cs
+}
+
+func printComparisons(w io.Writer, cs []comparison, threshold float64) {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "benchmark\told ns/op\tnew ns/op\tdelta\told B/op\tnew B/op\told allocs\tnew allocs\t")
+
+	for _, c := range cs {
+		mark := ""
+		if c.regressed(threshold) {
+			mark = " REGRESSION"
+		}
+
+		fmt.Fprintf(tw, "%s\t%.0f\t%.0f\t%+.1f%%%s\t%.0f\t%.0f\t%.0f\t%.0f\t\n",
+			c.name, c.oldNs, c.newNs, c.delta(), mark, c.oldB, c.newB, c.oldAllocs, c.newAllocs)
+	}
+
+	_ = tw.Flush()
+}
+
+func median(vs []float64) float64 {
+	if len(vs) == 0 {
+		return 0
+	}
+
+	s := append([]float64(nil), vs...)
+	sort.Float64s(s)
+
+	if len(s)%2 == 1 {
+		return s[len(s)/2]
+	}
+
+	return (s[len(s)/2-1] + s[len(s)/2]) / 2
+}
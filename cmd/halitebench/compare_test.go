@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const oldResults = `goos: linux
+goarch: amd64
+pkg: github.com/daved/halitego/ops
+BenchmarkParseBoard-8   	    5000	    300000 ns/op	   40000 B/op	     600 allocs/op
+BenchmarkParseBoard-8   	    5000	    100000 ns/op	   40000 B/op	     600 allocs/op
+BenchmarkParseBoard-8   	    5000	    200000 ns/op	   42000 B/op	     602 allocs/op
+PASS
+ok  	github.com/daved/halitego/ops	4.100s
+pkg: github.com/daved/halitego/geom
+BenchmarkObstacles-8    	  200000	      1000 ns/op	       0 B/op	       0 allocs/op
+BenchmarkObstacles-8    	  200000	      1200 ns/op	       0 B/op	       0 allocs/op
+BenchmarkRemoved-8      	  200000	      1000 ns/op
+PASS
+`
+
+const newResults = `pkg: github.com/daved/halitego/ops
+BenchmarkParseBoard-8   	    5000	    210000 ns/op	   30000 B/op	     500 allocs/op
+BenchmarkParseBoard-8   	    5000	    190000 ns/op	   30000 B/op	     500 allocs/op
+pkg: github.com/daved/halitego/geom
+BenchmarkObstacles-8    	  200000	      1320 ns/op	       0 B/op	       0 allocs/op
+BenchmarkAdded-8        	  200000	      1000 ns/op
+`
+
+func TestCompare(t *testing.T) {
+	cs := compare(parseResults(oldResults), parseResults(newResults))
+	if len(cs) != 2 {
+		t.Fatalf("want 2 benchmarks run at both revisions, got %+v", cs)
+	}
+
+	ds := []struct {
+		name         string
+		oldNs, newNs float64
+		oldB, newB   float64
+		delta        float64
+	}{
+		{"geom.BenchmarkObstacles-8", 1100, 1320, 0, 0, 20},
+		{"ops.BenchmarkParseBoard-8", 200000, 200000, 40000, 30000, 0},
+	}
+
+	for k, d := range ds {
+		c := cs[k]
+		if c.name != d.name || c.oldNs != d.oldNs || c.newNs != d.newNs || c.oldB != d.oldB || c.newB != d.newB {
+			t.Errorf("want %+v, got %+v", d, c)
+		}
+		if got := c.delta(); got < d.delta-1e-9 || got > d.delta+1e-9 {
+			t.Errorf("%s: want delta %v%%, got %v%%", d.name, d.delta, got)
+		}
+	}
+}
+
+func TestMedian(t *testing.T) {
+	ds := []struct {
+		vs   []float64
+		want float64
+	}{
+		{nil, 0},
+		{[]float64{3}, 3},
+		{[]float64{3, 1, 2}, 2},
+		{[]float64{4, 1, 3, 2}, 2.5},
+	}
+
+	for _, d := range ds {
+		if got := median(d.vs); got != d.want {
+			t.Errorf("median(%v): got %v, want %v", d.vs, got, d.want)
+		}
+	}
+}
+
+func TestReport(t *testing.T) {
+	ds := []struct {
+		threshold float64
+		status    int
+	}{
+		{25, 0},
+		{20, 0},
+		{10, exitRegressed},
+	}
+
+	for _, d := range ds {
+		var buf bytes.Buffer
+		if got := report(&buf, oldResults, newResults, d.threshold); got != d.status {
+			t.Errorf("threshold %v: want exit status %d, got %d", d.threshold, d.status, got)
+		}
+
+		marked := strings.Contains(buf.String(), "REGRESSION")
+		if marked != (d.status == exitRegressed) {
+			t.Errorf("threshold %v: want regression marked %v, got:\n%s", d.threshold, d.status == exitRegressed, buf.String())
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Command halitebench runs the benchmark suite at two revisions and reports
+// the difference, failing when any benchmark slows beyond a threshold.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const modPath = "github.com/daved/halitego"
+
+func main() {
+	var (
+		root      = "."
+		oldRev    = "HEAD"
+		newRev    = ""
+		bench     = "."
+		benchtime = ""
+		count     = 5
+		pkgs      = "./..."
+		threshold = 10.0
+		out       = ""
+	)
+
+	flag.StringVar(&root, "root", root, "halitego repository root")
+	flag.StringVar(&oldRev, "old", oldRev, "baseline revision")
+	flag.StringVar(&newRev, "new", newRev, "compared revision, the working tree if empty")
+	flag.StringVar(&bench, "bench", bench, "benchmarks to run")
+	flag.StringVar(&benchtime, "benchtime", benchtime, "benchmark time, the go test default if empty")
+	flag.IntVar(&count, "count", count, "runs of each benchmark")
+	flag.StringVar(&pkgs, "pkgs", pkgs, "space separated packages to benchmark")
+	flag.Float64Var(&threshold, "threshold", threshold, "percent slowdown in time/op regarded as a regression")
+	flag.StringVar(&out, "out", out, "directory to keep raw results in for use with benchstat")
+	flag.Parse()
+
+	b := benchmarker{
+		bench:     bench,
+		benchtime: benchtime,
+		count:     count,
+		pkgs:      strings.Fields(pkgs),
+	}
+
+	status, err := run(root, oldRev, newRev, b, threshold, out)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "halitebench:", err)
+		os.Exit(exitError)
+	}
+
+	os.Exit(status)
+}
+
+// Exit statuses.
+const (
+	exitError     = 1
+	exitRegressed = 2
+)
+
+func run(root, oldRev, newRev string, b benchmarker, threshold float64, out string) (int, error) {
+	stage, err := ioutil.TempDir("", "halitebench")
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = os.RemoveAll(stage) }()
+
+	var res [2]string
+	for k, rev := range []string{oldRev, newRev} {
+		name := rev
+		if name == "" {
+			name = "working tree"
+		}
+		fmt.Fprintf(os.Stderr, "benchmarking %s\n", name)
+
+		r, err := b.runAt(root, stage, rev)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %v", name, err)
+		}
+
+		res[k] = r
+	}
+
+	if out != "" {
+		for k, n := range []string{"old.txt", "new.txt"} {
+			if err := ioutil.WriteFile(filepath.Join(out, n), []byte(res[k]), 0644); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return report(os.Stdout, res[0], res[1], threshold), nil
+}
+
+// report prints the comparison of the raw results "old" and "cur" and
+// returns the exit status, which is exitRegressed if any benchmark slowed
+// by more than "threshold" percent.
+func report(w io.Writer, old, cur string, threshold float64) int {
+	cs := compare(parseResults(old), parseResults(cur))
+	printComparisons(w, cs, threshold)
+
+	for _, c := range cs {
+		if c.regressed(threshold) {
+			return exitRegressed
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+type dim struct {
+	w, h int
+}
+
+func parseSizes(s string) ([]dim, error) {
+	var ds []dim
+	for _, v := range strings.Split(s, ",") {
+		wh := strings.Split(strings.TrimSpace(v), "x")
+		if len(wh) != 2 {
+			return nil, fmt.Errorf("bad map size %q", v)
+		}
+
+		w, err := strconv.Atoi(wh[0])
+		if err != nil {
+			return nil, fmt.Errorf("bad map size %q: %v", v, err)
+		}
+		h, err := strconv.Atoi(wh[1])
+		if err != nil {
+			return nil, fmt.Errorf("bad map size %q: %v", v, err)
+		}
+
+		ds = append(ds, dim{w, h})
+	}
+
+	return ds, nil
+}
+
+// game describes a single match. bots are listed in seat order.
+type game struct {
+	bots []string
+	w, h int
+	seed int64
+}
+
+// result holds the outcome of a game indexed by seat. ships holds NaN when
+// the runner does not report ship counts.
+type result struct {
+	ranks []int
+	ships []float64
+	err   error
+}
+
+// schedule plays every lineup on every map size and seed. Seats are
+// shuffled per game so that no bot keeps a positional advantage.
+func schedule(bots []string, dims []dim, players, games int, seed int64) []game {
+	var gs []game
+	rng := rand.New(rand.NewSource(seed))
+
+	for _, lu := range lineups(bots, players) {
+		for _, d := range dims {
+			for i := 0; i < games; i++ {
+				seats := append([]string{}, lu...)
+				rng.Shuffle(len(seats), func(a, b int) {
+					seats[a], seats[b] = seats[b], seats[a]
+				})
+
+				gs = append(gs, game{
+					bots: seats,
+					w:    d.w,
+					h:    d.h,
+					seed: seed + int64(i),
+				})
+			}
+		}
+	}
+
+	return gs
+}
+
+// lineups returns each combination of "n" distinct bots, or a single lineup
+// repeating the available bots when there are fewer than "n".
+func lineups(bots []string, n int) [][]string {
+	if len(bots) < n {
+		var lu []string
+		for i := 0; i < n; i++ {
+			lu = append(lu, bots[i%len(bots)])
+		}
+
+		return [][]string{lu}
+	}
+
+	var lus [][]string
+	var comb func(start int, cur []string)
+	comb = func(start int, cur []string) {
+		if len(cur) == n {
+			lus = append(lus, append([]string{}, cur...))
+			return
+		}
+
+		for i := start; i < len(bots); i++ {
+			comb(i+1, append(cur, bots[i]))
+		}
+	}
+	comb(0, nil)
+
+	return lus
+}
+
+type runner interface {
+	run(game) result
+}
+
+func newRunner(name, engine, botBin string) (runner, error) {
+	switch name {
+	case "engine":
+		return &engineRunner{engine: engine, botBin: botBin}, nil
+	case "sim":
+		return nil, fmt.Errorf("no in-process simulator is available in this build")
+	}
+
+	return nil, fmt.Errorf("unknown runner %q", name)
+}
+
+// engineRunner plays games using the external halite engine binary in quiet
+// mode, which reports results as JSON.
+type engineRunner struct {
+	engine string
+	botBin string
+}
+
+func (r *engineRunner) run(g game) result {
+	args := []string{
+		"-t", "-q",
+		"-d", fmt.Sprintf("%d %d", g.w, g.h),
+		"-s", strconv.FormatInt(g.seed, 10),
+	}
+	for _, b := range g.bots {
+		args = append(args, fmt.Sprintf("%s -bot %s -seed %d", r.botBin, b, g.seed))
+	}
+
+	var out, errOut bytes.Buffer
+	cmd := exec.Command(r.engine, args...)
+	cmd.Stdout = &out
+	cmd.Stderr = &errOut
+
+	if err := cmd.Run(); err != nil {
+		return result{err: fmt.Errorf("%v: %s", err, strings.TrimSpace(errOut.String()))}
+	}
+
+	return parseEngineOutput(out.Bytes(), len(g.bots))
+}
+
+type engineOutput struct {
+	Stats map[string]struct {
+		Rank      int      `json:"rank"`
+		ShipCount *float64 `json:"total_ship_count"`
+	} `json:"stats"`
+}
+
+func parseEngineOutput(bs []byte, players int) result {
+	var eo engineOutput
+	if err := json.Unmarshal(bs, &eo); err != nil {
+		return result{err: fmt.Errorf("cannot parse engine output: %v", err)}
+	}
+
+	res := result{
+		ranks: make([]int, players),
+		ships: make([]float64, players),
+	}
+
+	for i := 0; i < players; i++ {
+		st, ok := eo.Stats[strconv.Itoa(i)]
+		if !ok {
+			return result{err: fmt.Errorf("engine output lacks stats for player %d", i)}
+		}
+
+		res.ranks[i] = st.Rank
+		res.ships[i] = math.NaN()
+		if st.ShipCount != nil {
+			res.ships[i] = *st.ShipCount
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,117 @@
+// Command halitematch runs registered bots against each other over many
+// seeds and map sizes and reports win rates and ratings.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	_ "github.com/daved/halitego/internal/bot/hyena"
+	_ "github.com/daved/halitego/internal/bot/lemming"
+	"github.com/daved/halitego/registry"
+)
+
+func main() {
+	var (
+		engine   = "halite"
+		botBin   = "gopherbot"
+		bots     = strings.Join(registry.Names(), ",")
+		sizes    = "240x160,288x192,384x256"
+		players  = 2
+		games    = 10
+		seed     = int64(1)
+		parallel = 1
+		run      = "engine"
+	)
+
+	flag.StringVar(&engine, "engine", engine, "path to the halite engine binary")
+	flag.StringVar(&botBin, "bot-bin", botBin, "path to the gopherbot binary")
+	flag.StringVar(&bots, "bots", bots, "comma separated bots to play")
+	flag.StringVar(&sizes, "sizes", sizes, "comma separated map sizes (WxH)")
+	flag.IntVar(&players, "players", players, "players per game (2 or 4)")
+	flag.IntVar(&games, "games", games, "seeds played per lineup and map size")
+	flag.Int64Var(&seed, "seed", seed, "first map seed")
+	flag.IntVar(&parallel, "parallel", parallel, "games run concurrently")
+	flag.StringVar(&run, "runner", run, "game runner (engine or sim)")
+	flag.Parse()
+
+	if err := runMain(run, engine, botBin, bots, sizes, players, games, seed, parallel); err != nil {
+		fmt.Fprintln(os.Stderr, "halitematch:", err)
+		os.Exit(1)
+	}
+}
+
+func runMain(run, engine, botBin, bots, sizes string, players, games int, seed int64, parallel int) error {
+	if players != 2 && players != 4 {
+		return fmt.Errorf("players must be 2 or 4")
+	}
+
+	var names []string
+	for _, b := range strings.Split(bots, ",") {
+		name, _, err := registry.Lookup(strings.TrimSpace(b))
+		if err != nil {
+			return err
+		}
+
+		names = append(names, name)
+	}
+
+	dims, err := parseSizes(sizes)
+	if err != nil {
+		return err
+	}
+
+	r, err := newRunner(run, engine, botBin)
+	if err != nil {
+		return err
+	}
+
+	gs := schedule(names, dims, players, games, seed)
+	rs := play(r, gs, parallel)
+
+	st := newStandings(names)
+	for k, g := range gs {
+		if rs[k].err != nil {
+			fmt.Fprintf(os.Stderr, "game %d (seed %d, %dx%d, %s): %v\n", k, g.seed, g.w, g.h, strings.Join(g.bots, " vs "), rs[k].err)
+			continue
+		}
+
+		st.add(g, rs[k])
+	}
+
+	st.print(os.Stdout)
+
+	return nil
+}
+
+func play(r runner, gs []game, parallel int) []result {
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	rs := make([]result, len(gs))
+	idx := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for k := range idx {
+				rs[k] = r.run(gs[k])
+			}
+		}()
+	}
+
+	for k := range gs {
+		idx <- k
+	}
+	close(idx)
+	wg.Wait()
+
+	return rs
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestParseEngineOutput(t *testing.T) {
+	ds := []struct {
+		name  string
+		out   string
+		err   string
+		ranks []int
+		ships []float64 // NaN for unreported
+	}{
+		{
+			"complete",
+			`{"stats": {"0": {"rank": 2, "total_ship_count": 40}, "1": {"rank": 1, "total_ship_count": 95}}}`,
+			"", []int{2, 1}, []float64{40, 95},
+		},
+		{
+			"no ship count",
+			`{"stats": {"0": {"rank": 1}, "1": {"rank": 2, "total_ship_count": 3}}}`,
+			"", []int{1, 2}, []float64{math.NaN(), 3},
+		},
+		{"no stats", `{"map_width": 240}`, "lacks stats for player 0", nil, nil},
+		{"missing player", `{"stats": {"0": {"rank": 1}}}`, "lacks stats for player 1", nil, nil},
+		{"invalid", `halite`, "cannot parse engine output", nil, nil},
+	}
+
+	for _, d := range ds {
+		res := parseEngineOutput([]byte(d.out), 2)
+
+		if d.err != "" {
+			if res.err == nil || !strings.Contains(res.err.Error(), d.err) {
+				t.Errorf("%s: want error containing %q, got %v", d.name, d.err, res.err)
+			}
+			continue
+		}
+		if res.err != nil {
+			t.Errorf("%s: want no error, got %v", d.name, res.err)
+			continue
+		}
+
+		for i := range d.ranks {
+			if res.ranks[i] != d.ranks[i] {
+				t.Errorf("%s: want ranks %v, got %v", d.name, d.ranks, res.ranks)
+			}
+
+			want, got := d.ships[i], res.ships[i]
+			if math.IsNaN(want) != math.IsNaN(got) || (!math.IsNaN(want) && want != got) {
+				t.Errorf("%s: want ships %v, got %v", d.name, d.ships, res.ships)
+			}
+		}
+	}
+}
+
+func TestLineups(t *testing.T) {
+	ds := []struct {
+		bots []string
+		n    int
+		want string
+	}{
+		{[]string{"a", "b", "c"}, 2, "a,b a,c b,c"},
+		{[]string{"a", "b", "c", "d"}, 4, "a,b,c,d"},
+		{[]string{"a", "b"}, 4, "a,b,a,b"},
+		{[]string{"a"}, 2, "a,a"},
+	}
+
+	for _, d := range ds {
+		var got []string
+		for _, lu := range lineups(d.bots, d.n) {
+			got = append(got, strings.Join(lu, ","))
+		}
+
+		if strings.Join(got, " ") != d.want {
+			t.Errorf("lineups(%v, %d): got %v, want %s", d.bots, d.n, got, d.want)
+		}
+	}
+}
+
+func TestScheduleSeats(t *testing.T) {
+	gs := schedule([]string{"a", "b"}, []dim{{240, 160}}, 2, 20, 1)
+	if len(gs) != 20 {
+		t.Fatalf("want 20 games, got %d", len(gs))
+	}
+
+	first := make(map[string]int)
+	for _, g := range gs {
+		first[g.bots[0]]++
+	}
+
+	if first["a"] == 0 || first["b"] == 0 {
+		t.Errorf("want each bot seated first in some games, got %v", first)
+	}
+}
+
+func TestStandingsElo(t *testing.T) {
+	total := func(st *standings) float64 {
+		var sum float64
+		for _, r := range st.rs {
+			sum += r.elo
+		}
+
+		return sum
+	}
+
+	st := newStandings([]string{"a", "b", "c"})
+	gs := []struct {
+		g   game
+		res result
+	}{
+		{game{bots: []string{"a", "b"}}, result{ranks: []int{1, 2}, ships: []float64{10, 0}}},
+		{game{bots: []string{"c", "b", "a", "a"}}, result{ranks: []int{2, 1, 4, 3}, ships: []float64{1, 2, 3, 4}}},
+		{game{bots: []string{"b", "c", "c", "a"}}, result{ranks: []int{3, 3, 1, 2}, ships: []float64{1, 2, 3, 4}}},
+	}
+
+	for _, g := range gs {
+		st.add(g.g, g.res)
+
+		if got := total(st); math.Abs(got-3*eloStart) > 1e-9 {
+			t.Errorf("want Elo total %v conserved after %v, got %v", 3*eloStart, g.g.bots, got)
+		}
+	}
+
+	if st.rs["a"].games != 4 || st.rs["a"].wins != 1 {
+		t.Errorf("want bot a to have played 4 seats and won 1, got %d and %d", st.rs["a"].games, st.rs["a"].wins)
+	}
+
+	st = newStandings([]string{"a"})
+	st.add(game{bots: []string{"a", "a"}}, result{ranks: []int{1, 2}, ships: []float64{1, 2}})
+	if got := st.rs["a"].elo; got != eloStart {
+		t.Errorf("want no Elo change when a bot only plays itself, got %v", got)
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"text/tabwriter"
+)
+
+const (
+	eloStart = 1500.0
+	eloK     = 32.0
+)
+
+type record struct {
+	name   string
+	games  int
+	wins   int
+	ships  float64
+	shipCt int
+	elo    float64
+}
+
+type standings struct {
+	rs map[string]*record
+}
+
+func newStandings(names []string) *standings {
+	st := &standings{rs: make(map[string]*record)}
+	for _, n := range names {
+		st.rs[n] = &record{name: n, elo: eloStart}
+	}
+
+	return st
+}
+
+// add records a game. Elo ratings are updated pairwise between all seats,
+// with the adjustment scaled so that a game carries the same weight
+// regardless of player count.
+func (st *standings) add(g game, res result) {
+	for i, b := range g.bots {
+		r := st.rs[b]
+		r.games++
+		if res.ranks[i] == 1 {
+			r.wins++
+		}
+		if !math.IsNaN(res.ships[i]) {
+			r.ships += res.ships[i]
+			r.shipCt++
+		}
+	}
+
+	n := len(g.bots)
+	deltas := make([]float64, n)
+
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			a, b := st.rs[g.bots[i]], st.rs[g.bots[j]]
+			if a == b {
+				continue
+			}
+
+			score := 0.5
+			if res.ranks[i] < res.ranks[j] {
+				score = 1
+			} else if res.ranks[i] > res.ranks[j] {
+				score = 0
+			}
+
+			exp := 1 / (1 + math.Pow(10, (b.elo-a.elo)/400))
+			d := eloK / float64(n-1) * (score - exp)
+
+			deltas[i] += d
+			deltas[j] -= d
+		}
+	}
+
+	for i, b := range g.bots {
+		st.rs[b].elo += deltas[i]
+	}
+}
+
+func (st *standings) print(w io.Writer) {
+	var rs []*record
+	for _, r := range st.rs {
+		rs = append(rs, r)
+	}
+
+	sort.Slice(rs, func(i, j int) bool {
+		if rs[i].elo == rs[j].elo {
+			return rs[i].name < rs[j].name
+		}
+
+		return rs[i].elo > rs[j].elo
+	})
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "BOT\tGAMES\tWINS\tWIN RATE\tAVG SHIPS\tELO")
+
+	for _, r := range rs {
+		rate, ships := "-", "-"
+		if r.games > 0 {
+			rate = fmt.Sprintf("%.1f%%", 100*float64(r.wins)/float64(r.games))
+		}
+		if r.shipCt > 0 {
+			ships = fmt.Sprintf("%.1f", r.ships/float64(r.shipCt))
+		}
+
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\t%.0f\n", r.name, r.games, r.wins, rate, ships, r.elo)
+	}
+
+	_ = tw.Flush()
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// verifyBuild compiles the staged submission using the staging directory as
+// GOPATH, mirroring how the game servers build it.
+func verifyBuild(stage string) error {
+	cmd := exec.Command("go", "build", "-o", os.DevNull, mainFile)
+	cmd.Dir = stage
+	cmd.Env = append(os.Environ(), "GOPATH="+stage, "GO111MODULE=off", "GOFLAGS=")
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("submission does not compile: %v\n%s", err, out)
+	}
+
+	return nil
+}
+
+func zipDir(dir, out string) (err error) {
+	f, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	zw := zip.NewWriter(f)
+
+	err = filepath.Walk(dir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+
+		h, err := zip.FileInfoHeader(fi)
+		if err != nil {
+			return err
+		}
+		h.Name = filepath.ToSlash(rel)
+		h.Method = zip.Deflate
+
+		w, err := zw.CreateHeader(h)
+		if err != nil {
+			return err
+		}
+
+		src, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = src.Close() }()
+
+		_, err = io.Copy(w, src)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
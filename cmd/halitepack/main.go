@@ -0,0 +1,71 @@
+// Command halitepack builds a Halite submission archive containing a single
+// bot, its halitego dependencies, and the vendored packages it imports.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const (
+	modPath  = "github.com/daved/halitego"
+	mainFile = "MyBot.go"
+)
+
+func main() {
+	var (
+		root    = "."
+		mainSrc = filepath.Join("cmd", "gopherbot", "main.go")
+		botName = ""
+		out     = "sub_halitego.zip"
+		verify  = true
+		keep    = false
+	)
+
+	flag.StringVar(&root, "root", root, "halitego repository root")
+	flag.StringVar(&mainSrc, "main", mainSrc, "bot main file relative to root")
+	flag.StringVar(&botName, "bot", botName, "bot to embed (defaults to the main file's default)")
+	flag.StringVar(&out, "out", out, "submission archive to write")
+	flag.BoolVar(&verify, "verify", verify, "verify the submission compiles in a GOPATH layout")
+	flag.BoolVar(&keep, "keep", keep, "keep the staging directory")
+	flag.Parse()
+
+	if err := run(root, mainSrc, botName, out, verify, keep); err != nil {
+		fmt.Fprintln(os.Stderr, "halitepack:", err)
+		os.Exit(1)
+	}
+}
+
+func run(root, mainSrc, botName, out string, verify, keep bool) error {
+	stage, err := ioutil.TempDir("", "halitepack")
+	if err != nil {
+		return err
+	}
+
+	if keep {
+		fmt.Fprintln(os.Stderr, "staging:", stage)
+	} else {
+		defer func() { _ = os.RemoveAll(stage) }()
+	}
+
+	p := newPacker(root, stage)
+
+	if err := p.packMain(filepath.Join(root, mainSrc), botName); err != nil {
+		return err
+	}
+
+	if err := p.packDeps(); err != nil {
+		return err
+	}
+
+	if verify {
+		if err := verifyBuild(stage); err != nil {
+			return err
+		}
+	}
+
+	return zipDir(stage, out)
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var botsPath = modPath + "/internal/bot/"
+
+// packer stages a submission in a GOPATH layout rooted at "stage".
+type packer struct {
+	root  string
+	stage string
+	fset  *token.FileSet
+	queue []string
+	seen  map[string]bool
+}
+
+func newPacker(root, stage string) *packer {
+	return &packer{
+		root:  root,
+		stage: stage,
+		fset:  token.NewFileSet(),
+		seen:  make(map[string]bool),
+	}
+}
+
+// packMain rewrites the bot main file so that only the selected bot is
+// imported and run by default, and stages it as MyBot.go.
+func (p *packer) packMain(file, botName string) error {
+	f, err := parser.ParseFile(p.fset, file, nil, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	if botName != "" {
+		if err := selectBot(f, botName); err != nil {
+			return err
+		}
+	}
+
+	return p.writeFile(f, filepath.Join(p.stage, mainFile))
+}
+
+// packDeps stages every non-standard package reachable from the staged
+// files. halitego packages are taken from the repository with their import
+// paths flattened, and all others are taken from the vendor directory.
+func (p *packer) packDeps() error {
+	for len(p.queue) > 0 {
+		imp := p.queue[0]
+		p.queue = p.queue[1:]
+
+		src := filepath.Join(p.root, "vendor", filepath.FromSlash(imp))
+		if strings.HasPrefix(imp, modPath+"/") {
+			src = filepath.Join(p.root, filepath.FromSlash(strings.TrimPrefix(imp, modPath+"/")))
+		}
+
+		if err := p.packDir(src, filepath.Join(p.stage, "src", filepath.FromSlash(flatten(imp)))); err != nil {
+			return fmt.Errorf("cannot pack %s: %v", imp, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *packer) packDir(src, dst string) error {
+	fis, err := ioutil.ReadDir(src)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+
+	for _, fi := range fis {
+		n := fi.Name()
+		if fi.IsDir() || !strings.HasSuffix(n, ".go") || strings.HasSuffix(n, "_test.go") {
+			continue
+		}
+
+		f, err := parser.ParseFile(p.fset, filepath.Join(src, n), nil, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+
+		if err := p.writeFile(f, filepath.Join(dst, n)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeFile flattens the imports of "f", queues them for packing, and writes
+// the result to "dst".
+func (p *packer) writeFile(f *ast.File, dst string) error {
+	for _, is := range f.Imports {
+		imp, err := strconv.Unquote(is.Path.Value)
+		if err != nil {
+			return err
+		}
+
+		if isStd(imp) {
+			continue
+		}
+
+		if !p.seen[imp] {
+			p.seen[imp] = true
+			p.queue = append(p.queue, imp)
+		}
+
+		is.Path.Value = strconv.Quote(flatten(imp))
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, p.fset, f); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(dst, buf.Bytes(), 0644)
+}
+
+// selectBot drops the imports of all bots other than "name" and sets the
+// "defaultBot" constant to "name".
+func selectBot(f *ast.File, name string) error {
+	want := botsPath + strings.ToLower(name)
+	var avail []string
+	found := false
+
+	for _, is := range f.Imports {
+		imp, _ := strconv.Unquote(is.Path.Value)
+		if !strings.HasPrefix(imp, botsPath) {
+			continue
+		}
+
+		avail = append(avail, path.Base(imp))
+		if imp == want {
+			found = true
+		}
+	}
+
+	if !found {
+		sort.Strings(avail)
+		return fmt.Errorf("unknown bot %q (available: %s)", name, strings.Join(avail, ", "))
+	}
+
+	for _, d := range f.Decls {
+		gd, ok := d.(*ast.GenDecl)
+		if !ok || gd.Tok != token.IMPORT {
+			continue
+		}
+
+		var specs []ast.Spec
+		for _, s := range gd.Specs {
+			imp, _ := strconv.Unquote(s.(*ast.ImportSpec).Path.Value)
+			if strings.HasPrefix(imp, botsPath) && imp != want {
+				continue
+			}
+
+			specs = append(specs, s)
+		}
+		gd.Specs = specs
+	}
+
+	var imps []*ast.ImportSpec
+	for _, is := range f.Imports {
+		imp, _ := strconv.Unquote(is.Path.Value)
+		if strings.HasPrefix(imp, botsPath) && imp != want {
+			continue
+		}
+
+		imps = append(imps, is)
+	}
+	f.Imports = imps
+
+	set := false
+	ast.Inspect(f, func(n ast.Node) bool {
+		vs, ok := n.(*ast.ValueSpec)
+		if !ok {
+			return true
+		}
+
+		for k, id := range vs.Names {
+			if id.Name != "defaultBot" || k >= len(vs.Values) {
+				continue
+			}
+
+			if bl, ok := vs.Values[k].(*ast.BasicLit); ok && bl.Kind == token.STRING {
+				bl.Value = strconv.Quote(strings.ToLower(name))
+				set = true
+			}
+		}
+
+		return true
+	})
+
+	if !set {
+		return fmt.Errorf("no defaultBot string constant found")
+	}
+
+	return nil
+}
+
+// flatten removes "internal" path elements from halitego import paths so
+// that packages remain importable from MyBot.go.
+func flatten(imp string) string {
+	if !strings.HasPrefix(imp, modPath+"/") {
+		return imp
+	}
+
+	var es []string
+	for _, e := range strings.Split(imp, "/") {
+		if e != "internal" {
+			es = append(es, e)
+		}
+	}
+
+	return strings.Join(es, "/")
+}
+
+func isStd(imp string) bool {
+	return !strings.Contains(strings.Split(imp, "/")[0], ".")
+}
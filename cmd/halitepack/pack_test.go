@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestFlatten(t *testing.T) {
+	ds := []struct {
+		imp  string
+		want string
+	}{
+		{modPath + "/ops", modPath + "/ops"},
+		{modPath + "/internal/bot/hyena", modPath + "/bot/hyena"},
+		{modPath + "/ops/internal/msg", modPath + "/ops/msg"},
+		{"github.com/codemodus/sigmon", "github.com/codemodus/sigmon"},
+		{"github.com/other/internal/pkg", "github.com/other/internal/pkg"},
+		{modPath + "go/internal/x", modPath + "go/internal/x"},
+	}
+
+	for _, d := range ds {
+		if got := flatten(d.imp); got != d.want {
+			t.Errorf("flatten(%q): got %q, want %q", d.imp, got, d.want)
+		}
+	}
+}
+
+func TestIsStd(t *testing.T) {
+	ds := []struct {
+		imp  string
+		want bool
+	}{
+		{"fmt", true},
+		{"go/ast", true},
+		{"net/http/httptest", true},
+		{modPath + "/ops", false},
+		{"golang.org/x/image/font", false},
+	}
+
+	for _, d := range ds {
+		if got := isStd(d.imp); got != d.want {
+			t.Errorf("isStd(%q): got %v, want %v", d.imp, got, d.want)
+		}
+	}
+}
+
+const mainSrc = `package main
+
+import (
+	"flag"
+
+	_ "github.com/daved/halitego/internal/bot/hyena"
+	_ "github.com/daved/halitego/internal/bot/lemming"
+	"github.com/daved/halitego/ops"
+)
+
+const defaultBot = "lemming"
+
+func main() {
+	flag.Parse()
+	_ = ops.Logger(nil)
+}
+`
+
+func TestSelectBot(t *testing.T) {
+	ds := []struct {
+		name    string
+		err     string
+		imports []string
+		dropped []string
+	}{
+		{"Hyena", "", []string{`"flag"`, `"github.com/daved/halitego/internal/bot/hyena"`, `"github.com/daved/halitego/ops"`}, []string{"bot/lemming"}},
+		{"lemming", "", []string{`"github.com/daved/halitego/internal/bot/lemming"`}, []string{"bot/hyena"}},
+		{"gopher", `unknown bot "gopher" (available: hyena, lemming)`, nil, nil},
+	}
+
+	for _, d := range ds {
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, "main.go", mainSrc, parser.ParseComments)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = selectBot(f, d.name)
+		if d.err != "" {
+			if err == nil || err.Error() != d.err {
+				t.Errorf("%s: want error %q, got %v", d.name, d.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: want no error, got %v", d.name, err)
+			continue
+		}
+
+		var buf bytes.Buffer
+		if err := format.Node(&buf, fset, f); err != nil {
+			t.Fatal(err)
+		}
+		src := buf.String()
+
+		for _, imp := range d.imports {
+			if !strings.Contains(src, imp) {
+				t.Errorf("%s: want import %s kept, got:\n%s", d.name, imp, src)
+			}
+		}
+		for _, imp := range d.dropped {
+			if strings.Contains(src, imp) {
+				t.Errorf("%s: want import of %s dropped, got:\n%s", d.name, imp, src)
+			}
+		}
+		if len(f.Imports) != 3 {
+			t.Errorf("%s: want 3 imports, got %d", d.name, len(f.Imports))
+		}
+
+		if want := `const defaultBot = "` + strings.ToLower(d.name) + `"`; !strings.Contains(src, want) {
+			t.Errorf("%s: want %s, got:\n%s", d.name, want, src)
+		}
+	}
+}
+
+func TestSelectBotNoDefault(t *testing.T) {
+	src := strings.Replace(mainSrc, `const defaultBot = "lemming"`, `var defaultBot = flag.String("bot", "", "")`, 1)
+
+	f, err := parser.ParseFile(token.NewFileSet(), "main.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := selectBot(f, "hyena"); err == nil {
+		t.Error("want an error when defaultBot is not a string constant")
+	}
+}
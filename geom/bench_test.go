@@ -0,0 +1,45 @@
+package geom_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/daved/halitego/geom"
+)
+
+// lateGame returns the planets and ships of a crowded 384x256 board: 28
+// planets and 440 ships, placed using "seed".
+func lateGame(seed int64) (ps, ss []geom.Location) {
+	rng := rand.New(rand.NewSource(seed))
+
+	for i := 0; i < 28; i++ {
+		r := 3 + rng.Float64()*9
+		ps = append(ps, geom.MakeLocation(r+rng.Float64()*(384-2*r), r+rng.Float64()*(256-2*r), r))
+	}
+
+	for i := 0; i < 440; i++ {
+		ss = append(ss, geom.MakeLocation(1+rng.Float64()*382, 1+rng.Float64()*254, 0.5))
+	}
+
+	return ps, ss
+}
+
+func BenchmarkObstacles(b *testing.B) {
+	ps, ss := lateGame(1)
+
+	var ms []geom.Marker
+	for _, p := range ps {
+		ms = append(ms, p)
+	}
+	for _, s := range ss {
+		ms = append(ms, s)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		s, p := ss[i%len(ss)], ps[i%len(ps)]
+		_ = geom.Obstacles(ms, geom.BufferedLocation(2, p, s), s)
+	}
+}
@@ -100,12 +100,17 @@ func PerpindicularLocation(buffer float64, dir Direction, b, a Marker) Location
 }
 
 // Obstacles demonstrates how the player might determine if the path
-// between two enitities is clear
+// between two enitities is clear. Markers centered on either entity are
+// taken to be the entities themselves and are ignored.
 func Obstacles(ms []Marker, b, a Marker) bool {
-	pfl, pfr, pbr, pbl := pathPolygon(b, a)
+	pfl, pfr, pbr, pbl := PathPolygon(b, a)
 	pmaxX, pminX, pmaxY, pminY := minmax(pfl, pfr, pbr, pbl)
 
 	for _, po := range ms {
+		if sameCenter(po, a) || sameCenter(po, b) {
+			continue
+		}
+
 		if !potentialObstacle(po, pmaxX, pminX, pmaxY, pminY) {
 			continue
 		}
@@ -118,7 +123,10 @@ func Obstacles(ms []Marker, b, a Marker) bool {
 	return false
 }
 
-func pathPolygon(b, a Marker) (h, i, j, k Locator) {
+// PathPolygon returns the corners of the area swept by Marker "a" when
+// traveling to Marker "b". The corners are ordered front-left, front-right,
+// back-right, and back-left.
+func PathPolygon(b, a Marker) (h, i, j, k Locator) {
 	pfl := PerpindicularLocation(-b.Radius()+a.Radius(), Left, b, a)
 	pfr := PerpindicularLocation(-b.Radius()+a.Radius(), Right, b, a)
 	pbr := PerpindicularLocation(0, Left, a, b)
@@ -127,6 +135,13 @@ func pathPolygon(b, a Marker) (h, i, j, k Locator) {
 	return pfl, pfr, pbr, pbl
 }
 
+func sameCenter(b, a Locator) bool {
+	bx, by := b.Coords()
+	ax, ay := a.Coords()
+
+	return bx == ax && by == ay
+}
+
 func minmax(coords ...Locator) (maxX, minX, maxY, minY float64) {
 	maxX, minX, maxY, minY = 0.0, 1000000000.0, 0.0, 1000000000.0
 	for _, c := range coords {
@@ -143,7 +158,7 @@ func minmax(coords ...Locator) (maxX, minX, maxY, minY float64) {
 func potentialObstacle(ob Marker, maxX, minX, maxY, minY float64) bool {
 	x, y := ob.Coords()
 	r := ob.Radius()
-	maxX, minX, maxY, minY = maxX+r, minX-r, maxY+r, minY+r
+	maxX, minX, maxY, minY = maxX+r, minX-r, maxY+r, minY-r
 
 	return x >= minX && x <= maxX && y >= minY && y <= maxY
 }
@@ -162,12 +177,16 @@ func obstacle(ob Marker, coords ...Locator) bool {
 	return false
 }
 
+// inPolygon reports whether Marker "m" covers a corner of, or has its center
+// within, the convex polygon described by "coords". The polygon is divided
+// into a fan of triangles; points on the shared edges of the fan fall within
+// multiple triangles, so any containing triangle suffices.
 func inPolygon(m Marker, coords ...Locator) bool {
 	if len(coords) < 3 {
 		return false
 	}
 
-	ct := 0
+	in := false
 	x, y := m.Coords()
 	a := coords[0]
 	b := coords[1]
@@ -182,13 +201,13 @@ func inPolygon(m Marker, coords ...Locator) bool {
 		}
 
 		if inTriangle(x, y, a, b, c) {
-			ct++
+			in = true
 		}
 
 		b = c
 	}
 
-	return ct%2 != 0
+	return in
 }
 
 func potentialIntrusions(gm Marker, coords ...Locator) []Marker {
@@ -55,3 +55,64 @@ func TestInTriangle(t *testing.T) {
 		}
 	}
 }
+
+func TestPotentialObstacle(t *testing.T) {
+	ds := []struct {
+		ob  Location
+		res bool
+	}{
+		{MakeLocation(5, 6, 2), true},
+		{MakeLocation(5, 4, 2), true},
+		{MakeLocation(5, 12, 2), true},
+		{MakeLocation(5, 2, 2), false},
+		{MakeLocation(13, 6, 2), false},
+	}
+
+	for _, d := range ds {
+		got := potentialObstacle(d.ob, 10, 0, 10, 5)
+		if got != d.res {
+			t.Errorf("got %v, want %v - %v within 0-10, 5-10", got, d.res, d.ob)
+		}
+	}
+}
+
+func TestInPolygon(t *testing.T) {
+	square := []Locator{
+		MakeLocation(0, 0, 0),
+		MakeLocation(10, 0, 0),
+		MakeLocation(10, 10, 0),
+		MakeLocation(0, 10, 0),
+	}
+
+	ds := []struct {
+		m   Location
+		res bool
+	}{
+		{MakeLocation(5, 5, 0), true},
+		{MakeLocation(2, 2, 0), true},
+		{MakeLocation(8, 3, 0), true},
+		{MakeLocation(3, 8, 0), true},
+		{MakeLocation(12, 5, 0), false},
+		{MakeLocation(11, 11, 2), true},
+	}
+
+	for _, d := range ds {
+		got := inPolygon(d.m, square...)
+		if got != d.res {
+			t.Errorf("got %v, want %v - %v in %v", got, d.res, d.m, square)
+		}
+	}
+}
+
+func TestObstaclesIgnoresEnds(t *testing.T) {
+	a := MakeLocation(0, 0, 0.5)
+	b := MakeLocation(20, 0, 0.5)
+
+	if Obstacles([]Marker{a, b}, b, a) {
+		t.Errorf("want the traveling marker and its target ignored")
+	}
+
+	if !Obstacles([]Marker{a, b, MakeLocation(10, 0, 0.5)}, b, a) {
+		t.Errorf("want a marker between the ends to obstruct")
+	}
+}
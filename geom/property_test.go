@@ -0,0 +1,376 @@
+package geom
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// propIters is the number of random cases checked by each property test.
+const propIters = 2000
+
+// tolerance bounds floating point error for coordinates within mapBound.
+const (
+	tolerance = 1e-6
+	mapBound  = 1e4
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) <= tolerance*math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+}
+
+// nearDegrees reports whether integer angles differ by no more than "slack"
+// degrees around the circle.
+func nearDegrees(a, b, slack int) bool {
+	d := ((a-b)%360 + 360) % 360
+
+	return d <= slack || 360-d <= slack
+}
+
+// usable reports whether all values are finite and within the map bound.
+func usable(vs ...float64) bool {
+	for _, v := range vs {
+		if math.IsNaN(v) || math.Abs(v) > mapBound {
+			return false
+		}
+	}
+
+	return true
+}
+
+func randLocation(rng *rand.Rand, maxRadius float64) Location {
+	return MakeLocation(rng.Float64()*400, rng.Float64()*300, rng.Float64()*maxRadius)
+}
+
+// rotate turns "l" by "r" radians about the point (cx, cy).
+func rotate(l Location, r, cx, cy float64) Location {
+	x, y := l.Coords()
+	x, y = x-cx, y-cy
+
+	return MakeLocation(
+		cx+x*math.Cos(r)-y*math.Sin(r),
+		cy+x*math.Sin(r)+y*math.Cos(r),
+		l.Radius(),
+	)
+}
+
+// apart returns two random markers whose edges are at least "gap" apart.
+func apart(rng *rand.Rand, gap float64) (Location, Location) {
+	for {
+		b, a := randLocation(rng, 10), randLocation(rng, 1)
+		if EdgeDistance(b, a) >= gap {
+			return b, a
+		}
+	}
+}
+
+func TestBoundDegreesProperties(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < propIters; i++ {
+		b, a := randLocation(rng, 0), randLocation(rng, 0)
+		if CenterDistance(b, a) < 1 {
+			continue
+		}
+
+		d := BoundDegrees(b, a)
+		if d < 0 || d >= 360 {
+			t.Fatalf("got %d, want [0,360) for %v from %v", d, b, a)
+		}
+
+		if r := BoundDegrees(a, b); !nearDegrees(r, d+180, 1) {
+			t.Errorf("got reverse %d, want %d+180 for %v from %v", r, d, b, a)
+		}
+
+		turn := rng.Intn(360)
+		rb := rotate(b, float64(turn)*math.Pi/180, 200, 150)
+		ra := rotate(a, float64(turn)*math.Pi/180, 200, 150)
+		if got := BoundDegrees(rb, ra); !nearDegrees(got, d+turn, 1) {
+			t.Errorf("got %d rotated by %d, want %d", got, turn, d+turn)
+		}
+	}
+}
+
+func TestBufferedLocationProperties(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+
+	for i := 0; i < propIters; i++ {
+		b, a := apart(rng, 5)
+		buf := rng.Float64() * 4
+
+		l := BufferedLocation(buf, b, a)
+
+		if got, want := CenterDistance(b, l), b.Radius()+a.Radius()+buf; !near(got, want) {
+			t.Errorf("got %v from target, want %v", got, want)
+		}
+
+		if got, want := CenterDistance(a, l)+CenterDistance(l, b), CenterDistance(a, b); !near(got, want) {
+			t.Errorf("got path length %v via %v, want %v", got, l, want)
+		}
+	}
+}
+
+func TestPerpindicularLocationProperties(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+
+	for i := 0; i < propIters; i++ {
+		b, a := apart(rng, 1)
+		buf := rng.Float64() * 4
+
+		l := PerpindicularLocation(buf, Left, b, a)
+		r := PerpindicularLocation(buf, Right, b, a)
+
+		for _, p := range []Location{l, r} {
+			if got, want := CenterDistance(b, p), b.Radius()+buf; !near(got, want) {
+				t.Errorf("got %v from center, want %v", got, want)
+			}
+
+			bx, by := b.Coords()
+			ax, ay := a.Coords()
+			px, py := p.Coords()
+			if dot := (px-bx)*(bx-ax) + (py-by)*(by-ay); !near(dot/CenterDistance(a, b), 0) {
+				t.Errorf("got %v not perpendicular to %v from %v", p, b, a)
+			}
+		}
+
+		lx, ly := l.Coords()
+		rx, ry := r.Coords()
+		bx, by := b.Coords()
+		if !near((lx+rx)/2, bx) || !near((ly+ry)/2, by) {
+			t.Errorf("got %v and %v not symmetric about %v", l, r, b)
+		}
+	}
+}
+
+func TestInPolygonProperties(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+
+	for i := 0; i < propIters; i++ {
+		b, a := apart(rng, 1)
+		h, j, k, m := PathPolygon(b, a)
+		cs := []Locator{h, j, k, m}
+
+		var cx, cy float64
+		for _, c := range cs {
+			x, y := c.Coords()
+			cx, cy = cx+x/4, cy+y/4
+		}
+
+		center := MakeLocation(cx, cy, 0)
+		if !inPolygon(center, cs...) {
+			t.Errorf("got center %v outside of %v", center, cs)
+		}
+
+		for s := 1; s < len(cs); s++ {
+			shifted := append(append([]Locator{}, cs[s:]...), cs[:s]...)
+			if !inPolygon(center, shifted...) {
+				t.Errorf("got center %v outside of %v", center, shifted)
+			}
+		}
+
+		far := MakeLocation(cx+1000, cy-1000, 0)
+		if inPolygon(far, cs...) {
+			t.Errorf("got %v inside of %v", far, cs)
+		}
+	}
+}
+
+func TestObstaclesProperties(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+
+	for i := 0; i < propIters; i++ {
+		b, a := apart(rng, 10)
+		bx, by := b.Coords()
+		ax, ay := a.Coords()
+		r := Radians(b, a)
+		side := a.Radius() + 0.5 + rng.Float64()
+
+		mx, my := ax+(bx-ax)/2, ay+(by-ay)/2
+		mid := MakeLocation(mx, my, 0.5)
+		if !Obstacles([]Marker{mid}, b, a) {
+			t.Errorf("got clear path from %v to %v through %v", a, b, mid)
+		}
+
+		above := MakeLocation(mx-side*math.Sin(r), my+side*math.Cos(r), 1)
+		below := MakeLocation(mx+side*math.Sin(r), my-side*math.Cos(r), 1)
+		if ga, gb := Obstacles([]Marker{above}, b, a), Obstacles([]Marker{below}, b, a); ga != gb {
+			t.Errorf("got %v for %v and %v for %v mirrored across path from %v to %v", ga, above, gb, below, a, b)
+		}
+
+		if Obstacles([]Marker{a, b}, b, a) {
+			t.Errorf("got path from %v to %v blocked by its own ends", a, b)
+		}
+
+		farX, farY := mx-100*math.Sin(r), my+100*math.Cos(r)
+		if far := MakeLocation(farX, farY, 1); Obstacles([]Marker{far}, b, a) {
+			t.Errorf("got blocked path from %v to %v by %v", a, b, far)
+		}
+
+		turn := rng.Float64() * 2 * math.Pi
+		rb, ra, rmid := rotate(b, turn, 200, 150), rotate(a, turn, 200, 150), rotate(mid, turn, 200, 150)
+		if !Obstacles([]Marker{rmid}, rb, ra) {
+			t.Errorf("got clear path from %v to %v through %v after rotation", ra, rb, rmid)
+		}
+	}
+}
+
+func FuzzDistances(f *testing.F) {
+	f.Add(0.0, 0.0, 1.0, 3.0, 4.0, 0.5)
+	f.Add(10.0, 20.0, 5.0, 10.0, 20.0, 0.5)
+
+	f.Fuzz(func(t *testing.T, bx, by, br, ax, ay, ar float64) {
+		if !usable(bx, by, br, ax, ay, ar) {
+			return
+		}
+
+		b, a := MakeLocation(bx, by, br), MakeLocation(ax, ay, ar)
+
+		d := CenterDistance(b, a)
+		if d < 0 || !near(d, CenterDistance(a, b)) {
+			t.Errorf("got asymmetric or negative distance %v", d)
+		}
+
+		if got, want := EdgeDistance(b, a), d-br-ar; !near(got, want) {
+			t.Errorf("got edge distance %v, want %v", got, want)
+		}
+	})
+}
+
+func FuzzAngles(f *testing.F) {
+	f.Add(0.0, 0.0, 1.0, 1.0)
+	f.Add(-5.0, 3.0, 7.0, -2.0)
+
+	f.Fuzz(func(t *testing.T, bx, by, ax, ay float64) {
+		if !usable(bx, by, ax, ay) {
+			return
+		}
+
+		b, a := MakeLocation(bx, by, 0), MakeLocation(ax, ay, 0)
+
+		r := Radians(b, a)
+		if r < -math.Pi || r > math.Pi {
+			t.Errorf("got radians %v, want [-pi,pi]", r)
+		}
+
+		if got, want := Degrees(b, a), r*180/math.Pi; !near(got, want) {
+			t.Errorf("got degrees %v, want %v", got, want)
+		}
+
+		d := BoundDegrees(b, a)
+		if d < 0 || d >= 360 {
+			t.Errorf("got bound degrees %d, want [0,360)", d)
+		}
+	})
+}
+
+func FuzzBufferedLocation(f *testing.F) {
+	f.Add(2.0, 100.0, 100.0, 8.0, 10.0, 10.0, 0.5)
+
+	f.Fuzz(func(t *testing.T, buf, bx, by, br, ax, ay, ar float64) {
+		if !usable(buf, bx, by, br, ax, ay, ar) {
+			return
+		}
+
+		b, a := MakeLocation(bx, by, br), MakeLocation(ax, ay, ar)
+		if CenterDistance(b, a) == 0 {
+			return
+		}
+
+		l := BufferedLocation(buf, b, a)
+		if got, want := CenterDistance(b, l), math.Abs(br+ar+buf); !near(got, want) {
+			t.Errorf("got %v from target, want %v", got, want)
+		}
+	})
+}
+
+func FuzzPerpindicularLocation(f *testing.F) {
+	f.Add(2.0, true, 100.0, 100.0, 8.0, 10.0, 10.0, 0.5)
+
+	f.Fuzz(func(t *testing.T, buf float64, left bool, bx, by, br, ax, ay, ar float64) {
+		if !usable(buf, bx, by, br, ax, ay, ar) {
+			return
+		}
+
+		dir := Right
+		if left {
+			dir = Left
+		}
+
+		b, a := MakeLocation(bx, by, br), MakeLocation(ax, ay, ar)
+
+		l := PerpindicularLocation(buf, dir, b, a)
+		if got, want := CenterDistance(b, l), math.Abs(br+buf); !near(got, want) {
+			t.Errorf("got %v from center, want %v", got, want)
+		}
+	})
+}
+
+func FuzzPathPolygon(f *testing.F) {
+	f.Add(100.0, 100.0, 8.0, 10.0, 10.0, 0.5)
+	f.Add(10.0, 10.0, 0.5, 17.0, 10.0, 0.5)
+
+	f.Fuzz(func(t *testing.T, bx, by, br, ax, ay, ar float64) {
+		if !usable(bx, by, br, ax, ay, ar) || br < 0 || ar < 0 {
+			return
+		}
+
+		b, a := MakeLocation(bx, by, br), MakeLocation(ax, ay, ar)
+		d := CenterDistance(b, a)
+		if d == 0 {
+			return
+		}
+
+		// the path is a rectangle as wide as "a" and as long as the
+		// distance between the centers
+		h, i, j, k := PathPolygon(b, a)
+		sides := []struct {
+			name       string
+			from, to   Locator
+			want       float64
+			fromCenter Locator
+		}{
+			{"front", h, i, 2 * ar, b},
+			{"back", k, j, 2 * ar, a},
+			{"left", h, k, d, nil},
+			{"right", i, j, d, nil},
+		}
+
+		for _, s := range sides {
+			if got := CenterDistance(s.from, s.to); !near(got, s.want) {
+				t.Errorf("got %s side %v, want %v", s.name, got, s.want)
+			}
+
+			if s.fromCenter == nil {
+				continue
+			}
+			for _, c := range []Locator{s.from, s.to} {
+				if got := CenterDistance(s.fromCenter, c); !near(got, ar) {
+					t.Errorf("got %s corner %v from center, want %v", s.name, got, ar)
+				}
+			}
+		}
+	})
+}
+
+func FuzzObstacles(f *testing.F) {
+	f.Add(100.0, 100.0, 8.0, 10.0, 10.0, 0.5, 55.0, 55.0, 0.5)
+	f.Add(100.0, 10.0, 8.0, 10.0, 10.0, 0.5, 55.0, 9.0, 1.0)
+
+	f.Fuzz(func(t *testing.T, bx, by, br, ax, ay, ar, ox, oy, or float64) {
+		if !usable(bx, by, br, ax, ay, ar, ox, oy, or) || br < 0 || ar < 0 || or < 0 {
+			return
+		}
+
+		b, a := MakeLocation(bx, by, br), MakeLocation(ax, ay, ar)
+		o := MakeLocation(ox, oy, or)
+
+		h, i, j, k := PathPolygon(b, a)
+		for _, c := range []Locator{h, i, j, k} {
+			if x, y := c.Coords(); math.IsInf(x, 0) || math.IsInf(y, 0) {
+				t.Errorf("got infinite corner %v", c)
+			}
+		}
+
+		_ = Obstacles([]Marker{o}, b, a)
+	})
+}
@@ -1,130 +1,325 @@
 package hyena
 
 import (
-	"math/rand"
-	"time"
+	"errors"
 
+	"github.com/daved/halitego/assign"
 	"github.com/daved/halitego/geom"
 	"github.com/daved/halitego/ops"
+	"github.com/daved/halitego/registry"
+	"github.com/daved/halitego/strategy"
 )
 
-// Logger describes the halitego logging behavior.
-type Logger interface {
-	Printf(format string, v ...interface{})
+// Hyena roles.
+const (
+	expander strategy.Role = "expander"
+	striker  strategy.Role = "striker"
+	defender strategy.Role = "defender"
+	evacuee  strategy.Role = "evacuee"
+	rammer   strategy.Role = "rammer"
+)
+
+// Striking squad parameters.
+const (
+	squadReach   = 10.0
+	maxSquadSize = 8
+	engageRange  = ops.AttackRange
+)
+
+// fightRange is the distance within which a defender holds position and
+// lets its weapon engage the enemy.
+const fightRange = 3.0
+
+func init() {
+	registry.Register("Hyena", func(c registry.Config) ops.Commander {
+		return New(c.Logger, c.InitialBoard,
+			strategy.WithSeed(c.Seed),
+			strategy.WithWorkers(c.Workers),
+		)
+	})
 }
 
 // Hyena ...
 type Hyena struct {
-	l    Logger
+	*strategy.Commander
 	iniB ops.Board
-	rng  *rand.Rand
+
+	striking bool
+	targets  map[int]ops.Planet
+	orders   map[int]ops.CommandMessenger
+	defense  strategy.DefensePlan
+	rams     map[int]strategy.Ram
+	blasts   []ops.Planet
+	doomed   map[int]bool
 }
 
 // New ...
-func New(l Logger, initialBoard ops.Board) *Hyena {
-	return &Hyena{
-		l:    l,
+func New(l ops.Logger, initialBoard ops.Board, opts ...strategy.Option) *Hyena {
+	bot := &Hyena{
 		iniB: initialBoard,
-		rng:  rand.New(rand.NewSource(time.Now().Unix())),
 	}
+
+	bot.Commander = strategy.New(l, append([]strategy.Option{
+		strategy.WithPreparer(bot.prepare),
+		strategy.WithRoleAssigner(bot.role),
+		strategy.WithRole(expander,
+			strategy.BehaviorFunc("evade", bot.evade),
+			strategy.BehaviorFunc("expand", bot.expand),
+		),
+		strategy.WithRole(striker,
+			strategy.BehaviorFunc("evade", bot.evade),
+			strategy.BehaviorFunc("strike", bot.strike),
+		),
+		strategy.WithRole(defender,
+			strategy.BehaviorFunc("evade", bot.evade),
+			strategy.BehaviorFunc("intercept", bot.intercept),
+		),
+		strategy.WithRole(evacuee, strategy.BehaviorFunc("undock", bot.undock)),
+		strategy.WithRole(rammer, strategy.BehaviorFunc("ram", bot.ram)),
+	}, opts...)...)
+
+	return bot
 }
 
-// Command ...
-func (bot *Hyena) Command(b ops.Board, id int) ops.CommandMessengers {
-	ss := b.Ships()[id]
-	var ms ops.CommandMessengers
+func (bot *Hyena) prepare(t *strategy.Turn) {
+	bot.striking = allOwned(t.Board.Planets())
+	bot.targets = nil
+	bot.orders = nil
+
+	bot.defense = strategy.PlanDefense(t.Board, t.ID, strategy.DefaultDefenseConfig)
+	for _, th := range bot.defense.Threats {
+		t.Log.Printf("threat: %s", th)
+	}
+
+	ss := ops.NewQuery(t.Ships()).Where(ops.ShipStatus(ops.Undocked), func(s ops.Ship) bool {
+		_, ok := bot.defense.Intercepts[s.ID()]
+		return !ok
+	}).All()
 
+	bot.rams = strategy.PlanRams(t.Board, t.ID, ss, strategy.DefaultRamConfig)
 	for _, s := range ss {
-		ms = append(ms, bot.messenger(b, id, s))
+		if r, ok := bot.rams[s.ID()]; ok {
+			t.Log.Printf("ship %d: %s", s.ID(), r)
+		}
+	}
+
+	bot.blasts = strategy.Doomed(t.Board, bot.rams)
+	bot.doomed = make(map[int]bool)
+	for _, p := range bot.blasts {
+		bot.doomed[p.ID()] = true
 	}
 
-	return ms
+	ss = ops.NewQuery(ss).Where(func(s ops.Ship) bool {
+		_, ok := bot.rams[s.ID()]
+		return !ok
+	}).All()
+
+	if !bot.striking {
+		bot.targets = targets(t, ss)
+		return
+	}
+
+	bot.orders = make(map[int]ops.CommandMessenger)
+
+	for _, q := range ops.GroupSquads(ss, squadReach) {
+		for q.Len() > 0 {
+			target := squadTarget(t.Board, t.ID, bot.doomed, q)
+			if target == nil {
+				break
+			}
+
+			var sq ops.Squad
+			sq, q = q.Split(target, maxSquadSize)
+
+			ms := t.NavigateSquad(obstacles(t), target, sq)
+			for k, s := range sq.Ships() {
+				bot.orders[s.ID()] = ms[k]
+			}
+		}
+	}
 }
 
-// messenger demonstrates how the player might direct their ships
-// in achieving victory
-func (bot *Hyena) messenger(b ops.Board, id int, s ops.Ship) ops.CommandMessenger {
+func (bot *Hyena) role(t *strategy.Turn, s ops.Ship) strategy.Role {
+	if bot.defense.Undock[s.ID()] {
+		return evacuee
+	}
+
 	if s.DockingStatus() != ops.Undocked {
-		return s.NoOp()
+		return strategy.DefaultRole
+	}
+
+	if _, ok := bot.defense.Intercepts[s.ID()]; ok {
+		return defender
 	}
 
-	ps := ops.PlanetsByProximity(b, s)
-	striking := bot.allOwned(ps)
+	if _, ok := bot.rams[s.ID()]; ok {
+		return rammer
+	}
+
+	if bot.striking {
+		return striker
+	}
+
+	return expander
+}
+
+// evade moves the ship out of the blast of the nearest planet about to
+// explode
+func (bot *Hyena) evade(t *strategy.Turn, s ops.Ship) (ops.CommandMessenger, bool) {
+	if !ops.InBlast(bot.blasts, s) {
+		return nil, false
+	}
+
+	p, _ := ops.NewQuery(bot.blasts).OrderBy(ops.PlanetEdgeDistance(s)).First()
+
+	return nav(t, geom.BufferedLocation(p.BlastRadius()-p.Radius()+1, p, s), s), true
+}
+
+// expand demonstrates how the player might claim planets, favoring the
+// planet assigned to the ship
+func (bot *Hyena) expand(t *strategy.Turn, s ops.Ship) (ops.CommandMessenger, bool) {
+	ps := ops.PlanetsByProximity(t.Board, s)
+	if p, ok := bot.targets[s.ID()]; ok {
+		ps = append([]ops.Planet{p}, ps...)
+	}
 
 	for _, p := range ps {
-		msg, err := s.Dock(p)
+		if bot.doomed[p.ID()] {
+			continue
+		}
+
+		msg, err := t.Dock(s, p)
 		if err == nil {
-			return msg
+			return msg, true
 		}
 
-		if aMsg, ok := bot.altMsg(err, id, striking, bpsLoad{b, p, s}); ok {
-			return aMsg
+		if errors.Is(err, ops.ErrNoPorts) || errors.Is(err, ops.ErrNoRights) {
+			continue
 		}
+
+		if errors.Is(err, ops.ErrNoJuncture) {
+			return nav(t, geom.BufferedLocation(2, p, s), s), true
+		}
+
+		return s.NoOp(), true
 	}
 
-	return s.NoOp()
+	return s.NoOp(), true
 }
 
-type bpsLoad struct {
-	b ops.Board
-	p ops.Planet
-	s ops.Ship
-}
+// strike demonstrates how the player might assault enemy planets once
+// all planets are owned, concentrating ships into squads which move and
+// engage together
+func (bot *Hyena) strike(t *strategy.Turn, s ops.Ship) (ops.CommandMessenger, bool) {
+	for _, p := range ops.PlanetsByProximity(t.Board, s) {
+		if bot.doomed[p.ID()] {
+			continue
+		}
 
-func (bot *Hyena) altMsg(err error, id int, striking bool, bps bpsLoad) (ops.CommandMessenger, bool) {
-	derr, ok := err.(ops.DockingError)
-	if !ok {
-		return bps.s.NoOp(), true
+		if msg, err := t.Dock(s, p); err == nil {
+			return msg, true
+		}
 	}
 
-	if !striking && (derr.NoPorts() || derr.NoRights()) {
-		return bps.s.NoOp(), false
+	if m, ok := bot.orders[s.ID()]; ok {
+		return m, true
 	}
 
-	if striking && !derr.NoRights() {
-		return bps.s.NoOp(), false
+	return s.NoOp(), true
+}
+
+// squadTarget selects the nearest enemy planet of squad "q" which is not
+// doomed. Squads far from the planet approach it, while nearby squads close
+// on the planet owner's nearest ship. Nil is returned if no enemy remains.
+func squadTarget(b ops.Board, id int, doomed map[int]bool, q ops.Squad) geom.Marker {
+	for _, p := range ops.PlanetsByProximity(b, q) {
+		if !p.Owned() || p.Owner() == id || doomed[p.ID()] {
+			continue
+		}
+
+		if geom.EdgeDistance(p, q) > engageRange {
+			return geom.BufferedLocation(2, p, q)
+		}
+
+		if s, ok := ops.NewQuery(b.Ships()[p.Owner()]).OrderBy(ops.ShipEdgeDistance(q)).First(); ok {
+			return s
+		}
 	}
 
-	if derr.NoJuncture() {
-		return bot.nav(0, bps.b, id, geom.BufferedLocation(2, bps.p, bps.s), bps.s), true
+	return nil
+}
+
+// intercept demonstrates how the player might protect docked ships by
+// meeting threatening enemies before they arrive
+func (bot *Hyena) intercept(t *strategy.Turn, s ops.Ship) (ops.CommandMessenger, bool) {
+	e, ok := bot.defense.Intercepts[s.ID()]
+	if !ok {
+		return nil, false
 	}
 
-	if striking {
-		ss := bps.b.Ships()[bps.p.Owner()]
-		s := ss[bot.rng.Intn(len(ss)-1)]
+	if geom.EdgeDistance(e, s) <= fightRange {
+		return s.NoOp(), true
+	}
 
-		return bot.nav(0, bps.b, id, s, bps.s), true
+	return nav(t, geom.BufferedLocation(2, strategy.Intercept(s, e), s), s), true
+}
+
+// undock abandons a planet when a threat cannot be contested
+func (bot *Hyena) undock(t *strategy.Turn, s ops.Ship) (ops.CommandMessenger, bool) {
+	msg, err := s.Undock()
+	if err != nil {
+		return s.NoOp(), true
 	}
 
-	return bps.s.NoOp(), true
+	return msg, true
 }
 
-// nav demonstrates how the player might negotiate obstacles between
-// a ship and its target
-func (bot *Hyena) nav(trial int, b ops.Board, id int, target geom.Marker, s ops.Ship) ops.CommandMessenger {
-	trial++
-	if trial > 256 {
-		return s.NoOp()
+// ram demonstrates how the player might trade ships for planet explosions
+// or for enemy ships of far greater health
+func (bot *Hyena) ram(t *strategy.Turn, s ops.Ship) (ops.CommandMessenger, bool) {
+	r, ok := bot.rams[s.ID()]
+	if !ok {
+		return nil, false
 	}
 
-	ms := append(b.PlanetsMarkers(), b.ShipsMarkers()[id]...)
-	ob := geom.Obstacles(ms, target, s)
-	if !ob {
-		bot.l.Printf("clear to nav")
-		return s.Navigate(target)
+	return nav(t, strategy.RamCourse(s, r), s), true
+}
+
+func nav(t *strategy.Turn, target geom.Marker, s ops.Ship) ops.CommandMessenger {
+	return t.Navigate(obstacles(t), target, s)
+}
+
+func obstacles(t *strategy.Turn) []geom.Marker {
+	return append(t.Board.PlanetsMarkers(), t.Board.ShipsMarkers()[t.ID]...)
+}
+
+// targets assigns ships "ws", those neither defending nor ramming, to the
+// planets of the expansion plan such that each planet receives no more ships
+// than allotted by the plan and total travel distance is minimal.
+func targets(t *strategy.Turn, ws []ops.Ship) map[int]ops.Planet {
+	plan := strategy.PlanExpansion(t.Board, t.ID, ws, strategy.DefaultPlanetWeights)
+	t.Log.Printf("expansion plan: %s", plan)
+
+	caps := make([]int, len(plan))
+	for k, v := range plan {
+		caps[k] = v.Ships
 	}
 
-	buf := float64(bot.rng.Intn(24) + 24)
-	dir := geom.Left
-	if time.Now().Nanosecond()%2 == 0 {
-		dir = geom.Right
+	res := assign.Solve(len(ws), caps, func(w, k int) float64 {
+		return geom.EdgeDistance(ws[w], plan[k].Planet)
+	})
+
+	ts := make(map[int]ops.Planet)
+	for w, k := range res {
+		if k != assign.Unassigned {
+			ts[ws[w].ID()] = plan[k].Planet
+		}
 	}
 
-	pl := geom.PerpindicularLocation(buf, dir, target, s)
-	return bot.nav(trial, b, id, pl, s)
+	return ts
 }
 
-func (bot *Hyena) allOwned(ps []ops.Planet) bool {
+func allOwned(ps []ops.Planet) bool {
 	for _, p := range ps {
 		if !p.Owned() {
 			return false
@@ -0,0 +1,133 @@
+package hyena
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"testing"
+
+	"github.com/daved/halitego/geom"
+	"github.com/daved/halitego/ops"
+	"github.com/daved/halitego/ops/opstest"
+	"github.com/daved/halitego/strategy"
+)
+
+func TestExpand(t *testing.T) {
+	l := log.New(ioutil.Discard, "", 0)
+
+	b := opstest.NewBoard(240, 160, 2).
+		Planet(50, 50, 5, opstest.PlanetPorts(1)).
+		Planet(120, 80, 5).
+		Ship(0, 57, 50).
+		Ship(0, 58, 52).
+		Ship(0, 40, 40, opstest.DockedOn(1)).
+		Ship(1, 230, 150).
+		Board()
+
+	r := opstest.Run(New(l, b), b, 0)
+	r.AssertDocks(t, 0, 0)
+	r.AssertThrusts(t, 1)
+	r.AssertNoOp(t, 2)
+	r.AssertOwnShips(t)
+	r.AssertOnMap(t)
+}
+
+func TestStrike(t *testing.T) {
+	l := log.New(ioutil.Discard, "", 0)
+
+	b := opstest.NewBoard(240, 160, 2).
+		Planet(50, 50, 5, opstest.PlanetPorts(1)).
+		Planet(180, 120, 5, opstest.PlanetPorts(1)).
+		Ship(0, 44, 50, opstest.DockedOn(0)).
+		Ship(0, 100, 100).
+		Ship(1, 186, 120, opstest.DockedOn(1)).
+		Board()
+
+	r := opstest.Run(New(l, b), b, 0)
+	r.AssertNoOp(t, 0)
+	r.AssertThrustsToward(t, 1, b.Planets()[1], 5)
+	r.AssertOnMap(t)
+}
+
+func TestDefend(t *testing.T) {
+	l := log.New(ioutil.Discard, "", 0)
+
+	// an enemy at "x" approaches ship 0, docked on planet 0, from the east
+	board := func(x float64) ops.Board {
+		return opstest.NewBoard(240, 160, 2).
+			Planet(120, 80, 6).
+			Planet(40, 30, 5).
+			Ship(0, 127, 80, opstest.DockedOn(0)).
+			Ship(0, 130, 90).
+			Ship(1, x, 80).
+			Board()
+	}
+
+	bot := New(l, board(152))
+	opstest.Run(bot, board(152), 0)
+
+	// the enemy's velocity is only known from its movement since the
+	// previous turn, so ship 1 leads it rather than heading for it
+	r := opstest.Run(bot, board(145), 0)
+	r.AssertNoOp(t, 0)
+	r.AssertThrustsToward(t, 1, geom.MakeLocation(127, 80, 0), 15)
+	r.AssertOnMap(t)
+}
+
+func TestEvade(t *testing.T) {
+	l := log.New(ioutil.Discard, "", 0)
+
+	// enemy ship 2 at "x" rams planet 0 from the west
+	board := func(x float64) ops.Board {
+		return opstest.NewBoard(240, 160, 2).
+			Planet(120, 80, 6, opstest.PlanetHealth(100)).
+			Planet(40, 30, 5).
+			Ship(0, 127, 80, opstest.DockedOn(0)).
+			Ship(0, 126, 90).
+			Ship(1, x, 80).
+			Board()
+	}
+
+	bot := New(l, board(100))
+	opstest.Run(bot, board(100), 0)
+
+	r := opstest.Run(bot, board(107), 0)
+	r.AssertThrustsToward(t, 1, geom.MakeLocation(132, 100, 0), 15)
+	r.AssertOnMap(t)
+}
+
+func TestWorkers(t *testing.T) {
+	l := log.New(ioutil.Discard, "", 0)
+
+	for seed := int64(1); seed <= 3; seed++ {
+		b := opstest.LateGame(seed)
+
+		seq := New(l, b, strategy.WithSeed(seed))
+		par := New(l, b, strategy.WithSeed(seed), strategy.WithWorkers(4))
+
+		for turn := 1; turn <= 2; turn++ {
+			want := opstest.Run(seq, b, 0).Message()
+			if got := opstest.Run(par, b, 0).Message(); got != want {
+				t.Errorf("seed %d, turn %d: got %q with 4 workers, want %q", seed, turn, got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkCommand(b *testing.B) {
+	l := log.New(ioutil.Discard, "", 0)
+	bd := opstest.LateGame(1)
+
+	for _, workers := range []int{1, 4} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			c := New(l, bd, strategy.WithWorkers(workers))
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_ = c.Command(bd, 0)
+			}
+		})
+	}
+}
@@ -1,92 +1,62 @@
 package lemming
 
 import (
-	"math/rand"
-	"time"
+	"errors"
 
 	"github.com/daved/halitego/geom"
 	"github.com/daved/halitego/ops"
+	"github.com/daved/halitego/registry"
+	"github.com/daved/halitego/strategy"
 )
 
-// Logger describes the halitego logging behavior.
-type Logger interface {
-	Printf(format string, v ...interface{})
+func init() {
+	registry.Register("Lemming", func(c registry.Config) ops.Commander {
+		return New(c.Logger, c.InitialBoard,
+			strategy.WithSeed(c.Seed),
+			strategy.WithWorkers(c.Workers),
+		)
+	})
 }
 
 // Lemming ...
 type Lemming struct {
-	l    Logger
+	*strategy.Commander
 	iniB ops.Board
-	rng  *rand.Rand
 }
 
 // New ...
-func New(l Logger, initialBoard ops.Board) *Lemming {
-	return &Lemming{
-		l:    l,
+func New(l ops.Logger, initialBoard ops.Board, opts ...strategy.Option) *Lemming {
+	bot := &Lemming{
 		iniB: initialBoard,
-		rng:  rand.New(rand.NewSource(time.Now().Unix())),
 	}
-}
-
-// Command ...
-func (bot *Lemming) Command(b ops.Board, id int) ops.CommandMessengers {
-	ss := b.Ships()[id]
-	var ms ops.CommandMessengers
 
-	for _, s := range ss {
-		ms = append(ms, bot.messenger(b, id, s))
-	}
+	bot.Commander = strategy.New(l, append([]strategy.Option{
+		strategy.WithRole(strategy.DefaultRole, strategy.BehaviorFunc("settle", bot.settle)),
+	}, opts...)...)
 
-	return ms
+	return bot
 }
 
-// messenger demonstrates how the player might direct their ships
+// settle demonstrates how the player might direct their ships
 // in achieving victory
-func (bot *Lemming) messenger(b ops.Board, id int, s ops.Ship) ops.CommandMessenger {
+func (bot *Lemming) settle(t *strategy.Turn, s ops.Ship) (ops.CommandMessenger, bool) {
 	if s.DockingStatus() != ops.Undocked {
-		return s.NoOp()
+		return s.NoOp(), true
 	}
 
-	ps := ops.PlanetsByProximity(b, s)
-
-	for _, p := range ps {
-		msg, err := s.Dock(p)
+	for _, p := range ops.PlanetsByProximity(t.Board, s) {
+		msg, err := t.Dock(s, p)
 		if err == nil {
-			return msg
+			return msg, true
 		}
-		derr, ok := err.(ops.DockingError)
-		if ok && (derr.NoRights() || derr.NoPorts()) {
+		if errors.Is(err, ops.ErrNoRights) || errors.Is(err, ops.ErrNoPorts) {
 			continue
 		}
-		if ok && derr.NoJuncture() {
-			return bot.nav(0, b, id, geom.BufferedLocation(2, p, s), s)
+		if errors.Is(err, ops.ErrNoJuncture) {
+			ms := append(t.Board.Markers(), t.Board.ShipsMarkers()[t.ID]...)
+			return t.Navigate(ms, geom.BufferedLocation(2, p, s), s), true
 		}
 	}
 
-	return s.NoOp()
-}
-
-// nav demonstrates how the player might negotiate obsticles between
-// a ship and its target
-func (bot *Lemming) nav(trial int, b ops.Board, id int, target geom.Marker, s ops.Ship) ops.CommandMessenger {
-	trial++
-	if trial > 256 {
-		return s.NoOp()
-	}
-
-	ms := append(b.Markers(), b.ShipsMarkers()[id]...)
-	ob := geom.Obstacles(ms, target, s)
-	if !ob {
-		return s.Navigate(target)
-	}
-
-	buf := float64(bot.rng.Intn(24) + 24)
-	dir := geom.Left
-	if time.Now().Nanosecond()%2 == 0 {
-		dir = geom.Right
-	}
-	pl := geom.PerpindicularLocation(buf, dir, target, s)
-
-	return bot.nav(trial, b, id, pl, s)
+	return s.NoOp(), true
 }
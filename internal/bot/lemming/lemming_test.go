@@ -0,0 +1,54 @@
+package lemming
+
+import (
+	"io/ioutil"
+	"log"
+	"testing"
+
+	"github.com/daved/halitego/ops"
+	"github.com/daved/halitego/ops/opstest"
+	"github.com/daved/halitego/strategy"
+)
+
+func TestSettle(t *testing.T) {
+	l := log.New(ioutil.Discard, "", 0)
+
+	b := opstest.NewBoard(240, 160, 2).
+		Planet(50, 50, 5).
+		Planet(180, 120, 5).
+		Ship(0, 57, 50).
+		Ship(0, 20, 20).
+		Ship(1, 230, 150).
+		Board()
+
+	r := opstest.Run(New(l, b), b, 0)
+	r.AssertDocks(t, 0, 0)
+	r.AssertThrusts(t, 1)
+	r.AssertOwnShips(t)
+	r.AssertOnMap(t)
+}
+
+func TestWorkers(t *testing.T) {
+	l := log.New(ioutil.Discard, "", 0)
+
+	crowded := opstest.NewBoard(240, 160, 2).
+		Planet(50, 50, 5, opstest.PlanetPorts(2)).
+		Planet(90, 50, 5, opstest.PlanetPorts(2)).
+		Ship(0, 58, 50).
+		Ship(0, 50, 58).
+		Ship(0, 42, 50).
+		Ship(0, 70, 50).
+		Ship(0, 66, 56).
+		Ship(1, 230, 150).
+		Board()
+
+	bs := []ops.Board{crowded, opstest.LateGame(1), opstest.LateGame(2), opstest.LateGame(3)}
+
+	for k, b := range bs {
+		want := opstest.Run(New(l, b, strategy.WithSeed(int64(k))), b, 0).Message()
+		got := opstest.Run(New(l, b, strategy.WithSeed(int64(k)), strategy.WithWorkers(4)), b, 0).Message()
+		if got != want {
+			t.Errorf("board %d: got %q with 4 workers, want %q", k, got, want)
+		}
+	}
+}
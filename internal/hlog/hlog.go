@@ -0,0 +1,267 @@
+// Package hlog provides leveled, structured logging for halitego bots.
+package hlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level represents logging severity.
+type Level int
+
+// Level values. Messages below the configured level are discarded.
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+	LevelOff
+)
+
+var levelNames = [...]string{"debug", "info", "warn", "error", "off"}
+
+// ParseLevel converts a level name to a Level.
+func ParseLevel(s string) (Level, error) {
+	for k, v := range levelNames {
+		if strings.EqualFold(s, v) {
+			return Level(k), nil
+		}
+	}
+
+	return LevelOff, fmt.Errorf("hlog: unknown level %q", s)
+}
+
+// String ...
+func (l Level) String() string {
+	if l < LevelDebug || l > LevelOff {
+		return "unknown"
+	}
+
+	return levelNames[l]
+}
+
+// Format represents the encoding of log entries.
+type Format int
+
+// Format values.
+const (
+	Text Format = iota
+	JSON
+)
+
+// ParseFormat converts a format name ("text" or "json") to a Format.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "text":
+		return Text, nil
+	case "json":
+		return JSON, nil
+	}
+
+	return Text, fmt.Errorf("hlog: unknown format %q", s)
+}
+
+// Field keys commonly attached to entries.
+const (
+	KeyTurn     = "turn"
+	KeyShip     = "ship"
+	KeyDecision = "decision"
+	KeyReason   = "reason"
+)
+
+type core struct {
+	mu   sync.Mutex
+	w    io.Writer
+	lvl  Level
+	fmt  Format
+	turn int
+}
+
+// Logger writes leveled entries with structured fields. Loggers derived
+// using With share output, level, and turn state with their parent.
+type Logger struct {
+	c  *core
+	kv []interface{}
+}
+
+// New ...
+func New(w io.Writer, lvl Level, f Format) *Logger {
+	return &Logger{
+		c: &core{
+			w:   w,
+			lvl: lvl,
+			fmt: f,
+		},
+	}
+}
+
+// SetOutput sets the destination of log entries.
+func (l *Logger) SetOutput(w io.Writer) {
+	l.c.mu.Lock()
+	defer l.c.mu.Unlock()
+
+	l.c.w = w
+}
+
+// SetTurn sets the turn number attached to subsequent entries.
+func (l *Logger) SetTurn(turn int) {
+	l.c.mu.Lock()
+	defer l.c.mu.Unlock()
+
+	l.c.turn = turn
+}
+
+// Enabled reports whether entries of the provided level are written.
+func (l *Logger) Enabled(lvl Level) bool {
+	return lvl >= l.c.lvl && lvl < LevelOff
+}
+
+// With returns a Logger which attaches the provided key/value pairs to every
+// entry.
+func (l *Logger) With(kv ...interface{}) *Logger {
+	return &Logger{
+		c:  l.c,
+		kv: append(append([]interface{}{}, l.kv...), kv...),
+	}
+}
+
+// Debug ...
+func (l *Logger) Debug(msg string, kv ...interface{}) {
+	l.log(LevelDebug, msg, kv)
+}
+
+// Info ...
+func (l *Logger) Info(msg string, kv ...interface{}) {
+	l.log(LevelInfo, msg, kv)
+}
+
+// Warn ...
+func (l *Logger) Warn(msg string, kv ...interface{}) {
+	l.log(LevelWarn, msg, kv)
+}
+
+// Error ...
+func (l *Logger) Error(msg string, kv ...interface{}) {
+	l.log(LevelError, msg, kv)
+}
+
+// Printf writes a debug entry and satisfies ops.Logger.
+func (l *Logger) Printf(format string, v ...interface{}) {
+	if !l.Enabled(LevelDebug) {
+		return
+	}
+
+	l.log(LevelDebug, strings.TrimSpace(fmt.Sprintf(format, v...)), nil)
+}
+
+// DecisionsEnabled reports whether Decision entries are written.
+func (l *Logger) DecisionsEnabled() bool {
+	return l.Enabled(LevelInfo)
+}
+
+// Decision writes an info entry describing the command chosen for a ship.
+func (l *Logger) Decision(shipID int, decision, reason string) {
+	l.log(LevelInfo, "decision", []interface{}{
+		KeyShip, shipID,
+		KeyDecision, decision,
+		KeyReason, reason,
+	})
+}
+
+func (l *Logger) log(lvl Level, msg string, kv []interface{}) {
+	if !l.Enabled(lvl) {
+		return
+	}
+
+	l.c.mu.Lock()
+	defer l.c.mu.Unlock()
+
+	fs := []interface{}{
+		"time", time.Now().UTC().Format(time.RFC3339Nano),
+		"level", lvl.String(),
+		KeyTurn, l.c.turn,
+		"msg", msg,
+	}
+	fs = append(fs, l.kv...)
+	fs = append(fs, kv...)
+
+	var line string
+	switch l.c.fmt {
+	case JSON:
+		line = encodeJSON(fs)
+	default:
+		line = encodeText(fs)
+	}
+
+	_, _ = io.WriteString(l.c.w, line+"\n")
+}
+
+func encodeJSON(kv []interface{}) string {
+	var sb strings.Builder
+	sb.WriteByte('{')
+
+	for i := 0; i < len(kv); i += 2 {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+
+		k, _ := json.Marshal(key(kv[i]))
+		sb.Write(k)
+		sb.WriteByte(':')
+
+		v, err := json.Marshal(value(kv, i+1))
+		if err != nil {
+			v, _ = json.Marshal(fmt.Sprint(value(kv, i+1)))
+		}
+		sb.Write(v)
+	}
+
+	sb.WriteByte('}')
+
+	return sb.String()
+}
+
+func encodeText(kv []interface{}) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(kv); i += 2 {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+
+		v := fmt.Sprint(value(kv, i+1))
+		if strings.ContainsAny(v, " \"=") || v == "" {
+			v = strconv.Quote(v)
+		}
+
+		sb.WriteString(key(kv[i]) + "=" + v)
+	}
+
+	return sb.String()
+}
+
+func key(k interface{}) string {
+	if s, ok := k.(string); ok {
+		return s
+	}
+
+	return fmt.Sprint(k)
+}
+
+func value(kv []interface{}, i int) interface{} {
+	if i >= len(kv) {
+		return nil
+	}
+
+	v := kv[i]
+	if err, ok := v.(error); ok {
+		return err.Error()
+	}
+
+	return v
+}
@@ -0,0 +1,131 @@
+package hlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLevels(t *testing.T) {
+	ds := []struct {
+		lvl  Level
+		want []string
+	}{
+		{LevelDebug, []string{"printf", "debug", "info", "decision", "warn", "error"}},
+		{LevelInfo, []string{"info", "decision", "warn", "error"}},
+		{LevelWarn, []string{"warn", "error"}},
+		{LevelError, []string{"error"}},
+		{LevelOff, nil},
+	}
+
+	for _, d := range ds {
+		var buf bytes.Buffer
+		l := New(&buf, d.lvl, JSON)
+
+		l.Printf("printf %d\n", 1)
+		l.Debug("debug")
+		l.Info("info")
+		l.Decision(1, "t 1 7 0", "default/settle")
+		l.Warn("warn")
+		l.Error("error")
+
+		var got []string
+		for _, e := range entries(t, &buf) {
+			got = append(got, strings.Fields(e["msg"].(string))[0])
+		}
+
+		if strings.Join(got, ",") != strings.Join(d.want, ",") {
+			t.Errorf("%s: got %v, want %v", d.lvl, got, d.want)
+		}
+
+		if l.DecisionsEnabled() != (d.lvl <= LevelInfo) {
+			t.Errorf("%s: got decisions enabled %v", d.lvl, l.DecisionsEnabled())
+		}
+	}
+}
+
+func TestFields(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, LevelInfo, JSON)
+	sl := l.With("bot", "hyena")
+
+	l.SetTurn(3)
+	sl.Decision(7, "d 7 2", "expander/expand")
+	l.SetTurn(4)
+	l.Info("turn", "ships", 2)
+
+	es := entries(t, &buf)
+	if len(es) != 2 {
+		t.Fatalf("want 2 entries, got %d", len(es))
+	}
+
+	want := map[string]interface{}{
+		"level":     "info",
+		KeyTurn:     3.0,
+		"msg":       "decision",
+		"bot":       "hyena",
+		KeyShip:     7.0,
+		KeyDecision: "d 7 2",
+		KeyReason:   "expander/expand",
+	}
+	for k, v := range want {
+		if es[0][k] != v {
+			t.Errorf("decision %s: got %v, want %v", k, es[0][k], v)
+		}
+	}
+
+	if es[1][KeyTurn] != 4.0 || es[1]["ships"] != 2.0 || es[1]["bot"] != nil {
+		t.Errorf("want turn 4 with 2 ships and no bot, got %v", es[1])
+	}
+}
+
+func TestText(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, LevelInfo, Text)
+
+	l.SetTurn(2)
+	l.Decision(5, "", "a b")
+
+	line := buf.String()
+	for _, want := range []string{"level=info", "turn=2", "msg=decision", "ship=5", `decision=""`, `reason="a b"`} {
+		if !strings.Contains(line, want) {
+			t.Errorf("want %s in %q", want, line)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	if lvl, err := ParseLevel("WARN"); err != nil || lvl != LevelWarn {
+		t.Errorf("got %v, %v, want warn", lvl, err)
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Errorf("want error for unknown level")
+	}
+	if f, err := ParseFormat("json"); err != nil || f != JSON {
+		t.Errorf("got %v, %v, want json", f, err)
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Errorf("want error for unknown format")
+	}
+}
+
+func entries(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+
+	var es []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+
+		e := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("bad entry %q: %v", line, err)
+		}
+
+		es = append(es, e)
+	}
+
+	return es
+}
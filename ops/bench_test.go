@@ -0,0 +1,36 @@
+package ops_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/daved/halitego/ops"
+	"github.com/daved/halitego/ops/opstest"
+)
+
+func BenchmarkParseBoard(b *testing.B) {
+	bld := opstest.RandomBoard(rand.New(rand.NewSource(1)), 384, 256, 4, 110, 28)
+	x, y := bld.Dimensions()
+	line := bld.Line()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := ops.ParseBoard(x, y, line); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPlanetsByProximity(b *testing.B) {
+	bd := opstest.LateGame(1)
+	ss := bd.Ships()[0]
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = ops.PlanetsByProximity(bd, ss[i%len(ss)])
+	}
+}
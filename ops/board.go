@@ -1,7 +1,7 @@
 package ops
 
 import (
-	"strings"
+	"fmt"
 
 	"github.com/daved/halitego/geom"
 )
@@ -15,46 +15,76 @@ type Board struct {
 	ss   [][]Ship
 }
 
-// makeBoard from a slice of game state tokens
-func makeBoard(xLen, yLen int, gameData string) Board {
-	tokens := strings.Split(gameData, " ")
-	pCt := readTokenInt(tokens, 0)
-	tokens = tokens[1:]
+// makeBoard from a line of game state
+func makeBoard(xLen, yLen int, gameData string) (Board, error) {
+	r := newTokenReader(gameData)
+
+	pCt, err := r.count()
+	if err != nil {
+		return Board{}, err
+	}
 
 	b := Board{
 		xLen: xLen,
 		yLen: yLen,
+		pCt:  pCt,
 		ss:   make([][]Ship, pCt),
 	}
 
 	for k := range b.ss {
-		curID := readTokenInt(tokens, 0)
+		curID, err := r.int()
+		if err != nil {
+			return b, err
+		}
 		if curID != k {
-			panic("curID is not iteration when making board")
+			return b, fmt.Errorf("player %d listed out of order at %d", curID, k)
 		}
 
-		shipCt := readTokenInt(tokens, 1)
-		tokens = tokens[2:]
+		shipCt, err := r.count()
+		if err != nil {
+			return b, err
+		}
 
 		for i := 0; i < shipCt; i++ {
-			s, trimmedTokens := makeShip(k, tokens)
-			tokens = trimmedTokens
+			s, err := makeShip(k, r)
+			if err != nil {
+				return b, err
+			}
 
 			b.ss[k] = append(b.ss[k], s)
 		}
 	}
 
-	plntCt := readTokenInt(tokens, 0)
-	tokens = tokens[1:]
+	plntCt, err := r.count()
+	if err != nil {
+		return b, err
+	}
 
 	for i := 0; i < plntCt; i++ {
-		p, trimmedTokens := makePlanet(tokens)
-		tokens = trimmedTokens
+		p, err := makePlanet(r)
+		if err != nil {
+			return b, err
+		}
 
 		b.ps = append(b.ps, p)
 	}
 
-	return b
+	if n := r.remaining(); n > 0 {
+		return b, fmt.Errorf("%d unexpected trailing tokens", n)
+	}
+
+	return b, nil
+}
+
+// ParseBoard makes a Board from a line of game state as sent by the game
+// engine each turn.
+func ParseBoard(xLen, yLen int, gameData string) (Board, error) {
+	b, err := makeBoard(xLen, yLen, gameData)
+	if err != nil {
+		return Board{}, fmt.Errorf("cannot parse board: %v", err)
+	}
+
+	return b, nil
 }
 
 // Dimensions ...
@@ -114,3 +144,25 @@ func (b *Board) Markers() []geom.Marker {
 
 	return ms
 }
+
+func (b *Board) ship(id int) (Ship, bool) {
+	for _, g := range b.ss {
+		for _, s := range g {
+			if s.id == id {
+				return s, true
+			}
+		}
+	}
+
+	return Ship{}, false
+}
+
+func (b *Board) planet(id int) (Planet, bool) {
+	for _, p := range b.ps {
+		if p.id == id {
+			return p, true
+		}
+	}
+
+	return Planet{}, false
+}
@@ -0,0 +1,218 @@
+package ops
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var (
+	update  = flag.Bool("update", false, "update golden files")
+	capture = flag.String("capture", "", "recording from which to capture parser test data")
+)
+
+func TestParseBoardGolden(t *testing.T) {
+	fs, err := filepath.Glob(filepath.Join("testdata", "parse", "*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fs) == 0 {
+		t.Fatal("no test data")
+	}
+
+	for _, f := range fs {
+		name := strings.TrimSuffix(filepath.Base(f), ".txt")
+
+		t.Run(name, func(t *testing.T) {
+			x, y, line := readParseData(t, f)
+
+			b, err := ParseBoard(x, y, line)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// the engine reports no velocities, so nor may the test data
+			for _, ss := range b.ss {
+				for _, s := range ss {
+					if s.velX != 0 || s.velY != 0 {
+						t.Errorf("ship %d: want zero velocity as sent by the engine, got (%v, %v)", s.id, s.velX, s.velY)
+					}
+				}
+			}
+
+			got := dumpBoard(b)
+			golden := strings.TrimSuffix(f, ".txt") + ".golden"
+
+			if *update {
+				if err := ioutil.WriteFile(golden, []byte(got), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			want, err := ioutil.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != string(want) {
+				t.Errorf("got:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
+
+// TestCaptureParseData writes the initial, middle, and final boards of a
+// recorded game to testdata/parse. Golden files are then written with
+// -update.
+func TestCaptureParseData(t *testing.T) {
+	if *capture == "" {
+		t.Skip("no recording to capture from")
+	}
+
+	f, err := os.Open(*capture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	rec, err := ReadRecording(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name := strings.TrimSuffix(filepath.Base(*capture), filepath.Ext(*capture))
+
+	for _, turn := range []int{0, rec.Turns() / 2, rec.Turns()} {
+		b, err := rec.Board(turn)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		x, y := b.Dimensions()
+		data := fmt.Sprintf("%d %d\n%s\n", x, y, rec.in[2+turn])
+		fn := filepath.Join("testdata", "parse", fmt.Sprintf("%dp_%s_turn%d.txt", b.PlayerCt(), name, turn))
+
+		if err := ioutil.WriteFile(fn, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestParseBoardErrors(t *testing.T) {
+	ds := []struct {
+		name string
+		line string
+	}{
+		{"empty", ""},
+		{"negative players", "-1 0"},
+		{"excess players", "9 0 0"},
+		{"players out of order", "2 1 0 0 0 0"},
+		{"truncated ship", "1 0 1 0 1.0 2.0 255"},
+		{"bad ship status", "1 0 1 0 1.0 2.0 255 0 0 7 0 0 0 0"},
+		{"bad float", "1 0 1 0 x 2.0 255 0 0 0 0 0 0 0"},
+		{"truncated planet", "1 0 0 1 0 1.0 2.0 1000 5"},
+		{"excess docked ships", "1 0 0 1 0 1.0 2.0 1000 5 3 0 1000 1 0 9 1"},
+		{"trailing tokens", "1 0 0 0 4"},
+	}
+
+	for _, d := range ds {
+		if _, err := ParseBoard(100, 100, d.line); err == nil {
+			t.Errorf("%s: want error, got nil", d.name)
+		}
+	}
+}
+
+func FuzzParseBoard(f *testing.F) {
+	fs, err := filepath.Glob(filepath.Join("testdata", "parse", "*.txt"))
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	for _, fn := range fs {
+		_, _, line := readParseData(f, fn)
+		f.Add(line)
+	}
+
+	f.Fuzz(func(t *testing.T, line string) {
+		b, err := ParseBoard(240, 160, line)
+		if err != nil {
+			return
+		}
+
+		if got := len(b.Ships()); got != b.PlayerCt() {
+			t.Errorf("want %d players of ships, got %d", b.PlayerCt(), got)
+		}
+
+		for _, p := range b.Planets() {
+			if got := len(p.ShipIDs()); got != p.DockedCt() {
+				t.Errorf("planet %d: want %d docked ship IDs, got %d", p.ID(), p.DockedCt(), got)
+			}
+		}
+	})
+}
+
+func readParseData(t testing.TB, filename string) (int, int, string) {
+	t.Helper()
+
+	f, err := os.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var x, y int
+	sc := bufio.NewScanner(f)
+	sc.Buffer(nil, 1<<20)
+
+	if !sc.Scan() {
+		t.Fatalf("%s: missing dimensions", filename)
+	}
+	if _, err := fmt.Sscan(sc.Text(), &x, &y); err != nil {
+		t.Fatalf("%s: %v", filename, err)
+	}
+
+	if !sc.Scan() {
+		t.Fatalf("%s: missing game state", filename)
+	}
+
+	return x, y, sc.Text()
+}
+
+// dumpBoard describes every parsed field of "b".
+func dumpBoard(b Board) string {
+	var sb strings.Builder
+
+	x, y := b.Dimensions()
+	fmt.Fprintf(&sb, "board %dx%d players=%d\n", x, y, b.PlayerCt())
+
+	for k, ss := range b.Ships() {
+		fmt.Fprintf(&sb, "player %d ships=%d\n", k, len(ss))
+
+		for _, s := range ss {
+			sx, sy := s.Coords()
+			fmt.Fprintf(&sb,
+				"  ship id=%d owner=%d x=%g y=%g r=%g health=%g vel=(%g,%g) status=%d planet=%d docking=%g cooldown=%g\n",
+				s.id, s.owner, sx, sy, s.Radius(), s.health, s.velX, s.velY,
+				s.sdStatus, s.planetID, s.docking, s.cooldown,
+			)
+		}
+	}
+
+	fmt.Fprintf(&sb, "planets=%d\n", len(b.Planets()))
+
+	for _, p := range b.Planets() {
+		px, py := p.Coords()
+		fmt.Fprintf(&sb,
+			"  planet id=%d x=%g y=%g r=%g health=%g ports=%g prod=%g rsrcs=%g owned=%g owner=%d docked=%g ships=%v\n",
+			p.id, px, py, p.Radius(), p.health, p.portCt, p.prodRate, p.rsrcs,
+			p.owned, p.owner, p.dockedCt, p.shipIDs,
+		)
+	}
+
+	return sb.String()
+}
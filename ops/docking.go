@@ -1,5 +1,11 @@
 package ops
 
+import (
+	"fmt"
+
+	"github.com/daved/halitego/geom"
+)
+
 // ShipDockingStatus represents possible ship docking states.
 type ShipDockingStatus int
 
@@ -11,29 +17,96 @@ const (
 	Undocking
 )
 
+// dockRadius is the distance from a planet's surface within which a ship
+// may dock.
+const dockRadius = 4.0
+
 // DockingError ...
 type DockingError interface {
 	error
 	NoJuncture() bool
 	NoRights() bool
 	NoPorts() bool
+	Busy() bool
+	PortsShort() int
+	Distance() float64
 }
 
-// DockingErr ...
+// DockingErr describes why a ship is unable to dock on a planet. It wraps
+// ErrNoJuncture, ErrNoRights, ErrNoPorts, and ErrNotUndocked as relevant.
 type DockingErr struct {
+	s     Ship
+	p     Planet
 	junct bool
 	right bool
 	ports bool
+	busy  bool
+	short int
+	dist  float64
+}
+
+// makeDockingErr validates the docking of ship "s" on planet "p" given the
+// number of ports already claimed on "p" during the current turn.
+func makeDockingErr(s Ship, p Planet, claimed int) *DockingErr {
+	dist := geom.CenterDistance(p, s) - p.Radius() - dockRadius
+	short := claimed - p.FreePorts() + 1
+
+	e := &DockingErr{
+		s:     s,
+		p:     p,
+		junct: dist > 0,
+		right: p.owned != 0 && p.Owner() != s.Owner(),
+		ports: short > 0,
+		busy:  s.sdStatus != Undocked,
+	}
+
+	if e.junct {
+		e.dist = dist
+	}
+	if e.ports {
+		e.short = short
+	}
+
+	return e
 }
 
 // Error ...
 func (e *DockingErr) Error() string {
-	return "cannot dock: " + e.reason()
+	return fmt.Sprintf("cannot dock ship %d on planet %d: %s", e.s.id, e.p.id, e.reason())
+}
+
+// Unwrap returns the sentinel errors which caused the docking to fail.
+func (e *DockingErr) Unwrap() []error {
+	var errs []error
+	if e.junct {
+		errs = append(errs, ErrNoJuncture)
+	}
+	if e.right {
+		errs = append(errs, ErrNoRights)
+	}
+	if e.ports {
+		errs = append(errs, ErrNoPorts)
+	}
+	if e.busy {
+		errs = append(errs, ErrNotUndocked)
+	}
+
+	return errs
 }
 
 // IsError ...
 func (e *DockingErr) IsError() bool {
-	return e.junct || e.right || e.ports
+	return e.junct || e.right || e.ports || e.busy
+}
+
+// Ship returns the ship which failed to dock.
+func (e *DockingErr) Ship() Ship {
+	return e.s
+}
+
+// Planet returns the planet the ship failed to dock on.
+func (e *DockingErr) Planet() Planet {
+	return e.p
 }
 
 // NoJuncture ...
@@ -51,6 +124,22 @@ func (e *DockingErr) NoPorts() bool {
 	return e.ports
 }
 
+// Busy reports whether the ship is docking, docked, or undocking.
+func (e *DockingErr) Busy() bool {
+	return e.busy
+}
+
+// PortsShort returns the number of ports lacking for the ship to dock.
+func (e *DockingErr) PortsShort() int {
+	return e.short
+}
+
+// Distance returns the distance the ship must still cover before it is able
+// to dock.
+func (e *DockingErr) Distance() float64 {
+	return e.dist
+}
+
 func (e *DockingErr) reason() string {
 	if !e.IsError() {
 		return "unknown"
@@ -58,13 +147,16 @@ func (e *DockingErr) reason() string {
 
 	s := ""
 	if e.junct {
-		s += " no proximity,"
+		s += fmt.Sprintf(" %s (%.2f away),", ErrNoJuncture, e.dist)
 	}
 	if e.right {
-		s += " no permission,"
+		s += fmt.Sprintf(" %s,", ErrNoRights)
 	}
 	if e.ports {
-		s += " no available port,"
+		s += fmt.Sprintf(" %s (%d short),", ErrNoPorts, e.short)
+	}
+	if e.busy {
+		s += fmt.Sprintf(" %s,", ErrNotUndocked)
 	}
 
 	return s[1 : len(s)-1]
@@ -0,0 +1,85 @@
+package ops
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Command validation errors. Errors returned by command constructors wrap
+// one or more of these and are able to be inspected using errors.Is. No
+// command is restricted by a ship's weapon cooldown (see Ship.Cooldown), so
+// there is no cooldown error; ErrDockingTransition covers ships which are
+// part way through docking or undocking.
+var (
+	ErrNoJuncture        = errors.New("no proximity")
+	ErrNoRights          = errors.New("no permission")
+	ErrNoPorts           = errors.New("no available port")
+	ErrNotUndocked       = errors.New("ship not undocked")
+	ErrNotDocked         = errors.New("ship not docked")
+	ErrDockingTransition = errors.New("docking transition in progress")
+	ErrOutOfBounds       = errors.New("destination out of bounds")
+	ErrExcessThrust      = errors.New("thrust magnitude out of range")
+)
+
+// ThrustErr ...
+type ThrustErr struct {
+	s    Ship
+	mag  int
+	ang  int
+	x, y float64
+	errs []error
+}
+
+// Error ...
+func (e *ThrustErr) Error() string {
+	return fmt.Sprintf("cannot thrust ship %d (%d at %d): %s", e.s.id, e.mag, e.ang, joinErrs(e.errs))
+}
+
+// Unwrap returns the validation errors which caused the thrust to fail.
+func (e *ThrustErr) Unwrap() []error {
+	return e.errs
+}
+
+// Ship returns the ship which failed to thrust.
+func (e *ThrustErr) Ship() Ship {
+	return e.s
+}
+
+// Destination returns the coordinates the ship would have reached.
+func (e *ThrustErr) Destination() (float64, float64) {
+	return e.x, e.y
+}
+
+// UndockErr ...
+type UndockErr struct {
+	s    Ship
+	errs []error
+}
+
+// Error ...
+func (e *UndockErr) Error() string {
+	return fmt.Sprintf("cannot undock ship %d: %s", e.s.id, joinErrs(e.errs))
+}
+
+// Unwrap returns the validation errors which caused the undock to fail.
+func (e *UndockErr) Unwrap() []error {
+	return e.errs
+}
+
+// Ship returns the ship which failed to undock.
+func (e *UndockErr) Ship() Ship {
+	return e.s
+}
+
+func joinErrs(errs []error) string {
+	s := ""
+	for _, err := range errs {
+		s += ", " + err.Error()
+	}
+
+	if len(s) == 0 {
+		return "unknown"
+	}
+
+	return s[2:]
+}
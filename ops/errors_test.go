@@ -0,0 +1,94 @@
+package ops_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/daved/halitego/ops"
+	"github.com/daved/halitego/ops/opstest"
+)
+
+func TestCommandErrors(t *testing.T) {
+	b := opstest.NewBoard(240, 160, 2).
+		Planet(50, 50, 5, opstest.PlanetPorts(2)).
+		Ship(0, 2, 2).
+		Ship(0, 48, 52, opstest.DockedOn(0)).
+		Ship(0, 52, 52, opstest.ShipStatus(ops.Docking, 0)).
+		Ship(1, 58, 50).
+		Board()
+
+	ss := b.Ships()[0]
+	p := b.Planets()[0]
+
+	thrust := func(s ops.Ship, mag, ang int) func() error {
+		return func() error {
+			_, err := s.Thrust(b, mag, ang)
+			return err
+		}
+	}
+	undock := func(s ops.Ship) func() error {
+		return func() error {
+			_, err := s.Undock()
+			return err
+		}
+	}
+	dock := func(s ops.Ship) func() error {
+		return func() error {
+			_, err := s.Dock(p)
+			return err
+		}
+	}
+
+	ds := []struct {
+		name string
+		fn   func() error
+		is   []error
+		not  []error
+	}{
+		{"thrust", thrust(ss[0], 7, 0), nil, nil},
+		{"thrust off map", thrust(ss[0], 7, 180), []error{ops.ErrOutOfBounds}, []error{ops.ErrExcessThrust}},
+		{"thrust excess", thrust(ss[0], 8, 0), []error{ops.ErrExcessThrust}, []error{ops.ErrOutOfBounds}},
+		{"thrust docked", thrust(ss[1], 7, 0), []error{ops.ErrNotUndocked}, nil},
+		{"undock", undock(ss[1]), nil, nil},
+		{"undock undocked", undock(ss[0]), []error{ops.ErrNotDocked}, []error{ops.ErrDockingTransition}},
+		{"undock docking", undock(ss[2]), []error{ops.ErrDockingTransition}, []error{ops.ErrNotDocked}},
+		{"dock far", dock(ss[0]), []error{ops.ErrNoJuncture, ops.ErrNoPorts}, []error{ops.ErrNoRights}},
+		{"dock foreign", dock(b.Ships()[1][0]), []error{ops.ErrNoRights}, []error{ops.ErrNoJuncture}},
+	}
+
+	for _, d := range ds {
+		err := d.fn()
+		if d.is == nil {
+			if err != nil {
+				t.Errorf("%s: want no error, got %v", d.name, err)
+			}
+			continue
+		}
+
+		for _, want := range d.is {
+			if !errors.Is(err, want) {
+				t.Errorf("%s: want %v in %v", d.name, want, err)
+			}
+		}
+		for _, want := range d.not {
+			if errors.Is(err, want) {
+				t.Errorf("%s: want no %v in %v", d.name, want, err)
+			}
+		}
+	}
+
+	_, err := ss[0].Thrust(b, 8, 180)
+	var te *ops.ThrustErr
+	if !errors.As(err, &te) || te.Ship().ID() != ss[0].ID() {
+		t.Fatalf("want ThrustErr for ship 0, got %v", err)
+	}
+	if x, y := te.Destination(); x != -6 || y > 2.0001 || y < 1.9999 {
+		t.Errorf("want destination (-6, 2), got (%v, %v)", x, y)
+	}
+
+	_, err = ss[0].Undock()
+	var ue *ops.UndockErr
+	if !errors.As(err, &ue) || ue.Ship().ID() != ss[0].ID() {
+		t.Errorf("want UndockErr for ship 0, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package ops
+
+import (
+	"math"
+
+	"github.com/daved/halitego/geom"
+)
+
+// Planet explosion parameters. A planet explodes when its health reaches
+// zero, damaging every ship within explosionRadius of its surface. Damage
+// falls off linearly from explosionDamage at the surface.
+const (
+	explosionRadius = 10.0
+	explosionDamage = 255.0
+)
+
+// BlastRadius returns the distance from the planet's center within which
+// ships are damaged if the planet explodes.
+func (p Planet) BlastRadius() float64 {
+	return p.Radius() + explosionRadius
+}
+
+// BlastDamage returns the damage dealt to a ship at "l" if the planet
+// explodes.
+func (p Planet) BlastDamage(l geom.Locator) float64 {
+	d := geom.CenterDistance(p, l) - p.Radius()
+	if d >= explosionRadius {
+		return 0
+	}
+
+	return explosionDamage * (1 - math.Max(d, 0)/explosionRadius)
+}
+
+// Casualties returns the damage dealt to each player's ships, indexed by
+// player ID, if the planet explodes. Damage to a ship is limited by its
+// health. Ships docked on the planet are destroyed with it.
+func (p Planet) Casualties(b Board) []float64 {
+	cs := make([]float64, len(b.ss))
+	for id, ss := range b.ss {
+		for _, s := range ss {
+			if s.sdStatus != Undocked && s.planetID == p.id {
+				cs[id] += s.health
+				continue
+			}
+
+			cs[id] += math.Min(p.BlastDamage(s), s.health)
+		}
+	}
+
+	return cs
+}
+
+// RamDamage returns the damage the planet is projected to take during the
+// next turn from ships which, at their current velocity, collide with it.
+// Ships deal damage equal to their health. The engine reports no
+// velocities, so "b" should hold velocities inferred from the previous turn
+// (see InferVelocities).
+func (p Planet) RamDamage(b Board) float64 {
+	var dmg float64
+	for _, ss := range b.ss {
+		for _, s := range ss {
+			if s.sdStatus != Undocked || (s.velX == 0 && s.velY == 0) {
+				continue
+			}
+
+			if pathDistance(s, p) <= p.Radius()+s.Radius() {
+				dmg += s.health
+			}
+		}
+	}
+
+	return dmg
+}
+
+// ImminentExplosions returns the planets projected to explode during the
+// next turn due to ships colliding with them.
+func ImminentExplosions(b Board) []Planet {
+	return QueryPlanets(b).Where(func(p Planet) bool {
+		return p.health <= p.RamDamage(b)
+	}).All()
+}
+
+// InBlast reports whether "l" is within the blast radius of any of the
+// provided planets.
+func InBlast(ps []Planet, l geom.Locator) bool {
+	for _, p := range ps {
+		if p.BlastDamage(l) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pathDistance returns the closest distance between the center of "l" and
+// the path of ship "s" over the next turn at its current velocity.
+func pathDistance(s Ship, l geom.Locator) float64 {
+	sx, sy := s.Coords()
+	lx, ly := l.Coords()
+
+	var u float64
+	if v := s.velX*s.velX + s.velY*s.velY; v > 0 {
+		u = math.Max(0, math.Min(1, ((lx-sx)*s.velX+(ly-sy)*s.velY)/v))
+	}
+
+	return math.Hypot(lx-(sx+u*s.velX), ly-(sy+u*s.velY))
+}
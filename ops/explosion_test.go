@@ -0,0 +1,57 @@
+package ops_test
+
+import (
+	"testing"
+
+	"github.com/daved/halitego/geom"
+	"github.com/daved/halitego/ops"
+	"github.com/daved/halitego/ops/opstest"
+)
+
+func TestExplosions(t *testing.T) {
+	// ship 0 at "x" heads for planet 0 from the west
+	board := func(x float64) ops.Board {
+		return opstest.NewBoard(240, 160, 2).
+			Planet(100, 60, 6, opstest.PlanetHealth(200)).
+			Planet(180, 120, 5).
+			Ship(0, x, 60).
+			Ship(1, 107, 60, opstest.DockedOn(0)).
+			Ship(1, 112, 60).
+			Ship(1, 150, 100).
+			Board()
+	}
+
+	cur := board(88)
+	b := ops.InferVelocities(board(81), cur)
+	p := b.Planets()[0]
+
+	if got := p.BlastRadius(); got != 16 {
+		t.Errorf("want blast radius 16, got %v", got)
+	}
+	if got := p.BlastDamage(geom.MakeLocation(111, 60, 0)); got != 127.5 {
+		t.Errorf("want half damage midway through the blast, got %v", got)
+	}
+	if got := p.BlastDamage(geom.MakeLocation(150, 100, 0)); got != 0 {
+		t.Errorf("want no damage beyond the blast, got %v", got)
+	}
+
+	cs := p.Casualties(b)
+	if cs[0] != 102 || cs[1] != 255+102 {
+		t.Errorf("want casualties [102 357], got %v", cs)
+	}
+
+	if got := p.RamDamage(b); got != 255 {
+		t.Errorf("want ram damage 255, got %v", got)
+	}
+	if got := p.RamDamage(cur); got != 0 {
+		t.Errorf("want no ram damage from a ship not known to move, got %v", got)
+	}
+
+	ps := ops.ImminentExplosions(b)
+	if len(ps) != 1 || ps[0].ID() != 0 {
+		t.Errorf("want planet 0 to explode, got %v", ps)
+	}
+	if !ops.InBlast(ps, b.Ships()[1][1]) || ops.InBlast(ps, b.Ships()[1][2]) {
+		t.Errorf("want only ship 2 within the blast")
+	}
+}
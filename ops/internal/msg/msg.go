@@ -49,6 +49,21 @@ func MakeThrust(id, magnitude, direction int) Thrust {
 	return Thrust{id, magnitude, direction}
 }
 
+// ID ...
+func (m Thrust) ID() int {
+	return m.id
+}
+
+// Magnitude ...
+func (m Thrust) Magnitude() int {
+	return m.magnitude
+}
+
+// Direction ...
+func (m Thrust) Direction() int {
+	return m.direction
+}
+
 // Message ...
 func (m Thrust) Message() string {
 	return fmt.Sprintf("t %d %d %d", m.id, m.magnitude, m.direction)
@@ -65,6 +80,16 @@ func MakeDock(id, planetID int) Dock {
 	return Dock{id, planetID}
 }
 
+// ID ...
+func (m Dock) ID() int {
+	return m.id
+}
+
+// PlanetID ...
+func (m Dock) PlanetID() int {
+	return m.planetID
+}
+
 // Message ...
 func (m Dock) Message() string {
 	return fmt.Sprintf("d %d %d", m.id, m.planetID)
@@ -80,6 +105,11 @@ func MakeUndock(id int) Undock {
 	return Undock{id}
 }
 
+// ID ...
+func (m Undock) ID() int {
+	return m.id
+}
+
 // Message ...
 func (m Undock) Message() string {
 	return fmt.Sprintf("u %d", m.id)
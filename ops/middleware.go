@@ -0,0 +1,235 @@
+package ops
+
+import (
+	"fmt"
+	"io"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/daved/halitego/ops/internal/msg"
+)
+
+// CommanderFunc adapts a function into a Commander.
+type CommanderFunc func(Board, int) CommandMessengers
+
+// Command ...
+func (f CommanderFunc) Command(b Board, id int) CommandMessengers {
+	return f(b, id)
+}
+
+// Middleware wraps a Commander with additional behavior.
+type Middleware func(Commander) Commander
+
+// Chain wraps the Commander with the provided middleware. The first
+// middleware is outermost.
+func Chain(c Commander, mws ...Middleware) Commander {
+	for i := len(mws) - 1; i >= 0; i-- {
+		c = mws[i](c)
+	}
+
+	return c
+}
+
+// Timing logs the time taken by each turn.
+func Timing(l Logger) Middleware {
+	return func(next Commander) Commander {
+		return CommanderFunc(func(b Board, id int) CommandMessengers {
+			start := time.Now()
+			ms := next.Command(b, id)
+			l.Printf("   Command Duration: %s\n", time.Since(start))
+
+			return ms
+		})
+	}
+}
+
+// Recover logs the stack of any panic raised by the Commander and falls
+// back to a NoOp for every ship. Operations already recover panics and count
+// them as failed turns, so panics recovered here are hidden from any
+// FailurePolicy. Recover is meant for Commanders run outside of Operations.
+func Recover(l Logger) Middleware {
+	return func(next Commander) Commander {
+		return CommanderFunc(func(b Board, id int) (ms CommandMessengers) {
+			defer func() {
+				r := recover()
+				if r == nil {
+					return
+				}
+
+				logError(l, "Command Panic", "panic", r, "stack", string(debug.Stack()))
+				ms = noOps(b, id)
+			}()
+
+			return next.Command(b, id)
+		})
+	}
+}
+
+// Validate drops commands which the game engine would reject: commands for
+// ships not owned by the player, repeated commands for a ship, and thrusts,
+// docks, or undocks which fail validation. Dropped commands are logged.
+func Validate(l Logger) Middleware {
+	return func(next Commander) Commander {
+		return CommanderFunc(func(b Board, id int) CommandMessengers {
+			ms := next.Command(b, id)
+
+			ss := make(map[int]Ship)
+			for _, s := range b.Ships()[id] {
+				ss[s.id] = s
+			}
+
+			ps := make(map[int]Planet)
+			for _, p := range b.Planets() {
+				ps[p.id] = p
+			}
+
+			r := NewDockReservations(b)
+			seen := make(map[int]bool)
+			var vms CommandMessengers
+
+			for _, m := range ms {
+				if err := validate(b, r, ss, ps, seen, m); err != nil {
+					l.Printf("   Dropped Command %q: %v\n", m.Message(), err)
+					continue
+				}
+
+				vms = append(vms, m)
+			}
+
+			return vms
+		})
+	}
+}
+
+func validate(b Board, r *DockReservations, ss map[int]Ship, ps map[int]Planet, seen map[int]bool, m CommandMessenger) error {
+	var shipID int
+	switch v := m.(type) {
+	case msg.Thrust:
+		shipID = v.ID()
+	case msg.Dock:
+		shipID = v.ID()
+	case msg.Undock:
+		shipID = v.ID()
+	default:
+		return nil
+	}
+
+	s, ok := ss[shipID]
+	if !ok {
+		return fmt.Errorf("ship %d is not owned", shipID)
+	}
+
+	if seen[shipID] {
+		return fmt.Errorf("ship %d already commanded", shipID)
+	}
+	seen[shipID] = true
+
+	var err error
+	switch v := m.(type) {
+	case msg.Thrust:
+		_, err = s.Thrust(b, v.Magnitude(), v.Direction())
+	case msg.Dock:
+		p, ok := ps[v.PlanetID()]
+		if !ok {
+			return fmt.Errorf("planet %d does not exist", v.PlanetID())
+		}
+		_, err = r.Dock(s, p)
+	case msg.Undock:
+		_, err = s.Undock()
+	}
+
+	return err
+}
+
+// Record writes the turn number and resulting message of each turn to "w".
+func Record(w io.Writer) Middleware {
+	return func(next Commander) Commander {
+		turn := 0
+
+		return CommanderFunc(func(b Board, id int) CommandMessengers {
+			turn++
+			ms := next.Command(b, id)
+			fmt.Fprintf(w, "%d %s\n", turn, msg.Messengers(ms).Message())
+
+			return ms
+		})
+	}
+}
+
+// Metrics accumulates per-turn statistics. It is safe for concurrent use.
+type Metrics struct {
+	mu    sync.Mutex
+	turns int
+	cmds  int
+	total time.Duration
+	max   time.Duration
+}
+
+// Middleware returns a Middleware which records into the Metrics.
+func (m *Metrics) Middleware() Middleware {
+	return func(next Commander) Commander {
+		return CommanderFunc(func(b Board, id int) CommandMessengers {
+			start := time.Now()
+			ms := next.Command(b, id)
+			d := time.Since(start)
+
+			m.mu.Lock()
+			defer m.mu.Unlock()
+
+			m.turns++
+			m.cmds += len(ms)
+			m.total += d
+			if d > m.max {
+				m.max = d
+			}
+
+			return ms
+		})
+	}
+}
+
+// Turns returns the number of turns recorded.
+func (m *Metrics) Turns() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.turns
+}
+
+// Commands returns the number of commands issued.
+func (m *Metrics) Commands() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.cmds
+}
+
+// MeanDuration returns the mean time taken by a turn.
+func (m *Metrics) MeanDuration() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.turns == 0 {
+		return 0
+	}
+
+	return m.total / time.Duration(m.turns)
+}
+
+// MaxDuration returns the longest time taken by a turn.
+func (m *Metrics) MaxDuration() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.max
+}
+
+func noOps(b Board, id int) CommandMessengers {
+	var ms CommandMessengers
+	for _, s := range b.Ships()[id] {
+		ms = append(ms, s.NoOp())
+	}
+
+	return ms
+}
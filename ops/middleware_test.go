@@ -0,0 +1,174 @@
+package ops_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/daved/halitego/ops"
+	"github.com/daved/halitego/ops/opstest"
+)
+
+type captureLogger struct {
+	lines []string
+}
+
+func (l *captureLogger) Printf(format string, v ...interface{}) {
+	l.lines = append(l.lines, strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (l *captureLogger) count(prefix string) int {
+	ct := 0
+	for _, line := range l.lines {
+		if strings.HasPrefix(line, prefix) {
+			ct++
+		}
+	}
+
+	return ct
+}
+
+func middlewareBoard() ops.Board {
+	return opstest.NewBoard(240, 160, 2).
+		Planet(50, 50, 5, opstest.PlanetPorts(2)).
+		Ship(0, 58, 50).
+		Ship(0, 50, 58).
+		Ship(0, 48, 52, opstest.DockedOn(0)).
+		Ship(0, 2, 2).
+		Ship(1, 200, 140).
+		Board()
+}
+
+func TestChain(t *testing.T) {
+	var calls []string
+
+	mw := func(name string) ops.Middleware {
+		return func(next ops.Commander) ops.Commander {
+			return ops.CommanderFunc(func(b ops.Board, id int) ops.CommandMessengers {
+				calls = append(calls, name+" in")
+				ms := next.Command(b, id)
+				calls = append(calls, name+" out")
+
+				return ms
+			})
+		}
+	}
+
+	c := ops.Chain(ops.CommanderFunc(func(b ops.Board, id int) ops.CommandMessengers {
+		calls = append(calls, "commander")
+		return nil
+	}), mw("a"), mw("b"))
+
+	c.Command(middlewareBoard(), 0)
+
+	want := "a in,b in,commander,b out,a out"
+	if got := strings.Join(calls, ","); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	b := middlewareBoard()
+	ss, es := b.Ships()[0], b.Ships()[1]
+	p := b.Planets()[0]
+
+	dock := func(s ops.Ship) ops.CommandMessenger {
+		m, err := s.Dock(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return m
+	}
+	thrust := func(s ops.Ship, mag, ang int) ops.CommandMessenger {
+		m, _ := s.Thrust(b, mag, ang)
+		return m
+	}
+	undock := func(s ops.Ship) ops.CommandMessenger {
+		m, _ := s.Undock()
+		return m
+	}
+
+	ms := ops.CommandMessengers{
+		dock(ss[0]),           // kept
+		thrust(ss[0], 7, 90),  // duplicate
+		dock(ss[1]),           // no port after ship 0 docks
+		undock(ss[2]),         // kept
+		thrust(ss[3], 7, 180), // off the map
+		thrust(ss[3], 8, 0),   // excess thrust
+		undock(ss[3]),         // not docked
+		thrust(es[0], 7, 0),   // foreign
+		ss[3].NoOp(),          // kept
+	}
+
+	l := &captureLogger{}
+	c := ops.Chain(ops.CommanderFunc(func(ops.Board, int) ops.CommandMessengers {
+		return ms
+	}), ops.Validate(l))
+
+	var got []string
+	for _, m := range c.Command(b, 0) {
+		got = append(got, m.Message())
+	}
+
+	want := []string{"d 0 0", "u 2", ""}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if n := l.count("Dropped Command"); n != 6 {
+		t.Errorf("want 6 dropped commands logged, got %d: %q", n, l.lines)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	b := middlewareBoard()
+	l := &captureLogger{}
+
+	c := ops.Chain(ops.CommanderFunc(func(ops.Board, int) ops.CommandMessengers {
+		panic("boom")
+	}), ops.Recover(l))
+
+	ms := c.Command(b, 0)
+	if len(ms) != len(b.Ships()[0]) {
+		t.Fatalf("want a NoOp for each of %d ships, got %d commands", len(b.Ships()[0]), len(ms))
+	}
+	for _, m := range ms {
+		if m.Message() != "" {
+			t.Errorf("want NoOp, got %q", m.Message())
+		}
+	}
+
+	if l.count("Command Panic: panic=boom") != 1 {
+		t.Errorf("want panic logged, got %q", l.lines)
+	}
+}
+
+func TestRecordAndMetrics(t *testing.T) {
+	b := middlewareBoard()
+	l := &captureLogger{}
+
+	var buf bytes.Buffer
+	var m ops.Metrics
+
+	c := ops.Chain(ops.CommanderFunc(func(b ops.Board, id int) ops.CommandMessengers {
+		return ops.CommandMessengers{b.Ships()[id][2].NoOp(), b.Ships()[id][3].Navigate(b.Planets()[0])}
+	}), m.Middleware(), ops.Record(&buf), ops.Timing(l))
+
+	c.Command(b, 0)
+	c.Command(b, 0)
+
+	if m.Turns() != 2 || m.Commands() != 4 || m.MaxDuration() < m.MeanDuration() {
+		t.Errorf("want 2 turns of 2 commands, got %d turns of %d commands", m.Turns(), m.Commands())
+	}
+
+	want := "1  t 3 7 45\n2  t 3 7 45\n"
+	if buf.String() != want {
+		t.Errorf("got recorded %q, want %q", buf.String(), want)
+	}
+
+	if l.count("Command Duration") != 2 {
+		t.Errorf("want 2 durations logged, got %q", l.lines)
+	}
+}
@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"runtime/debug"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/daved/halitego/ops/internal/msg"
 )
@@ -17,6 +19,35 @@ type Logger interface {
 	Printf(format string, v ...interface{})
 }
 
+// TurnLogger describes loggers which attach the current turn to entries.
+type TurnLogger interface {
+	Logger
+	SetTurn(turn int)
+}
+
+// ErrorLogger describes loggers able to write entries at error level.
+// Failures are written using Error when the Logger provides it, and using
+// Printf otherwise.
+type ErrorLogger interface {
+	Logger
+	Error(msg string, kv ...interface{})
+}
+
+// logError writes a failure along with key/value pairs describing it.
+func logError(l Logger, msg string, kv ...interface{}) {
+	if el, ok := l.(ErrorLogger); ok {
+		el.Error(msg, kv...)
+		return
+	}
+
+	var sb strings.Builder
+	for i := 0; i+1 < len(kv); i += 2 {
+		fmt.Fprintf(&sb, " %v=%v", kv[i], kv[i+1])
+	}
+
+	l.Printf("   %s:%s\n", msg, sb.String())
+}
+
 // CommandMessenger ...
 type CommandMessenger interface {
 	msg.Messenger
@@ -38,20 +69,86 @@ type Operations struct {
 	iniB Board
 	r    *bufio.Reader
 	w    io.Writer
+	rec  io.Writer
+	mws  []Middleware
+	pol  FailurePolicy
+	errs int
+	stop sync.Once
 	done chan struct{}
 }
 
+// FailurePolicy decides whether Operations should continue after a failed
+// turn given the number of consecutive failed turns and the latest error.
+type FailurePolicy func(consecutive int, err error) bool
+
+// MaxConsecutiveFailures returns a FailurePolicy which stops Operations once
+// "n" turns in a row have failed.
+func MaxConsecutiveFailures(n int) FailurePolicy {
+	return func(consecutive int, err error) bool {
+		return consecutive < n
+	}
+}
+
+// Option configures Operations.
+type Option func(*Operations)
+
+// WithInput sets the source of game engine messages. The default is stdin.
+func WithInput(r io.Reader) Option {
+	return func(o *Operations) {
+		o.r = bufio.NewReader(r)
+	}
+}
+
+// WithOutput sets the destination of bot messages. The default is stdout.
+func WithOutput(w io.Writer) Option {
+	return func(o *Operations) {
+		o.w = w
+	}
+}
+
+// WithRecorder tees every line received from and sent to the game engine to
+// "w" so that the game is able to be replayed using ReadRecording.
+func WithRecorder(w io.Writer) Option {
+	return func(o *Operations) {
+		o.rec = w
+	}
+}
+
+// WithMiddleware wraps the Commander provided to Run with the provided
+// middleware. The first middleware is outermost.
+func WithMiddleware(mws ...Middleware) Option {
+	return func(o *Operations) {
+		o.mws = append(o.mws, mws...)
+	}
+}
+
+// WithFailurePolicy sets the FailurePolicy consulted after a failed turn.
+// By default, Operations always continue.
+func WithFailurePolicy(p FailurePolicy) Option {
+	return func(o *Operations) {
+		o.pol = p
+	}
+}
+
 // New ...
-func New(botName string) *Operations {
+func New(botName string, opts ...Option) *Operations {
 	o := &Operations{
 		r:    bufio.NewReader(os.Stdin),
 		w:    os.Stdout,
 		done: make(chan struct{}),
 	}
 
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	o.id = o.readLineInt()
 	o.xLen, o.yLen = o.readLineInts()
-	o.iniB = makeBoard(o.xLen, o.yLen, o.readLineString())
+	b, err := ParseBoard(o.xLen, o.yLen, o.readLineString())
+	if err != nil {
+		panic(err)
+	}
+	o.iniB = b
 
 	o.send(botName)
 
@@ -68,13 +165,12 @@ func (o *Operations) InitialBoard() Board {
 	return o.iniB
 }
 
-// Stop ...
+// Stop ends Run after the current turn. It is safe to call more than once
+// and from multiple goroutines.
 func (o *Operations) Stop() {
-	select {
-	case <-o.done:
-	default:
+	o.stop.Do(func() {
 		close(o.done)
-	}
+	})
 }
 
 // Wait ...
@@ -84,6 +180,8 @@ func (o *Operations) Wait() {
 
 // Run gathers and submits game commands to the GameCommunicator.
 func (o *Operations) Run(l Logger, c Commander) {
+	c = Chain(c, o.mws...)
+
 	for i := 1; ; i++ {
 		select {
 		case <-o.done:
@@ -95,30 +193,92 @@ func (o *Operations) Run(l Logger, c Commander) {
 }
 
 func (o *Operations) runIteration(l Logger, iter int, c Commander) {
+	if tl, ok := l.(TurnLogger); ok {
+		tl.SetTurn(iter)
+	}
+
 	l.Printf("--- Turn %v\n", iter)
 
-	b := makeBoard(o.xLen, o.yLen, o.readLineString())
-	l.Printf("   Parsed Board")
+	bs, err := o.readLineErr()
+	if err != nil {
+		if err != io.EOF {
+			logError(l, "Read Error", "error", err)
+		}
 
-	ms := c.Command(b, o.id)
+		o.Stop()
+		return
+	}
 
-	sm := msg.Messengers(ms).Message()
-	l.Printf("   System Message: %s\n", sm)
+	sm, err := o.command(l, string(bs), c)
+	if err == nil {
+		o.errs = 0
+	} else {
+		o.errs++
+		logError(l, "Turn Failed", "consecutive", o.errs, "error", err)
+	}
 
+	l.Printf("   System Message: %s\n", sm)
 	o.send(sm)
+
+	if err != nil && o.pol != nil && !o.pol(o.errs, err) {
+		logError(l, "Stopping", "reason", "failure policy exceeded")
+		o.Stop()
+	}
+}
+
+// command parses the board and gathers the Commander's message. Any panic
+// is recovered and returned as an error along with an empty message, which
+// remains valid to send.
+func (o *Operations) command(l Logger, line string, c Commander) (sm string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			sm, err = "", fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+		}
+	}()
+
+	b, err := ParseBoard(o.xLen, o.yLen, line)
+	if err != nil {
+		return "", err
+	}
+	l.Printf("   Parsed Board")
+
+	ms := c.Command(b, o.id)
+
+	return msg.Messengers(ms).Message(), nil
 }
 
 func (o *Operations) send(msg string) {
 	fmt.Fprintf(o.w, "%s\n", msg)
+	o.record(recOut, msg)
 }
 
 func (o *Operations) readLine() []byte {
-	bs, err := o.r.ReadBytes('\n')
+	bs, err := o.readLineErr()
 	if err != nil {
 		panic(err)
 	}
 
-	return bytes.TrimSpace(bs)
+	return bs
+}
+
+func (o *Operations) readLineErr() ([]byte, error) {
+	bs, err := o.r.ReadBytes('\n')
+	if err != nil && (err != io.EOF || len(bytes.TrimSpace(bs)) == 0) {
+		return nil, err
+	}
+
+	bs = bytes.TrimSpace(bs)
+	o.record(recIn, string(bs))
+
+	return bs, nil
+}
+
+func (o *Operations) record(prefix, line string) {
+	if o.rec == nil {
+		return
+	}
+
+	fmt.Fprintf(o.rec, "%s%s\n", prefix, line)
 }
 
 func (o *Operations) readLineString() string {
@@ -148,29 +308,3 @@ func (o *Operations) readLineInts() (int, int) {
 
 	return x, y
 }
-
-func readTokenString(tokens []string, k int) string {
-	if k >= len(tokens) {
-		panic("index out of token range")
-	}
-
-	return tokens[k]
-}
-
-func readTokenInt(tokens []string, k int) int {
-	n, err := strconv.Atoi(readTokenString(tokens, k))
-	if err != nil {
-		panic(err)
-	}
-
-	return n
-}
-
-func readTokenFloat(tokens []string, k int) float64 {
-	n, err := strconv.ParseFloat(readTokenString(tokens, k), 64)
-	if err != nil {
-		panic(err)
-	}
-
-	return n
-}
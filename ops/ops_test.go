@@ -0,0 +1,125 @@
+package ops_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/daved/halitego/internal/hlog"
+	"github.com/daved/halitego/ops"
+	"github.com/daved/halitego/ops/opstest"
+)
+
+func engineInput(turns int) string {
+	b := opstest.NewBoard(240, 160, 2).
+		Planet(120, 80, 10).
+		Ship(0, 10, 20).
+		Ship(1, 200, 140)
+
+	x, y := b.Dimensions()
+	in := fmt.Sprintf("0\n%d %d\n%s\n", x, y, b.Line())
+	for i := 0; i < turns; i++ {
+		in += b.Line() + "\n"
+	}
+
+	return in
+}
+
+func TestRunFailures(t *testing.T) {
+	panicky := func(panics func(turn int) bool) ops.Commander {
+		turn := 0
+
+		return ops.CommanderFunc(func(b ops.Board, id int) ops.CommandMessengers {
+			turn++
+			if panics(turn) {
+				panic(fmt.Sprintf("turn %d", turn))
+			}
+
+			return ops.CommandMessengers{b.Ships()[id][0].Navigate(b.Planets()[0])}
+		})
+	}
+
+	always := func(int) bool { return true }
+	odd := func(turn int) bool { return turn%2 == 1 }
+
+	ds := []struct {
+		name   string
+		panics func(int) bool
+		opts   []ops.Option
+		want   []string
+	}{
+		{"no policy", always, nil, []string{"", "", "", "", ""}},
+		{"policy", always, []ops.Option{ops.WithFailurePolicy(ops.MaxConsecutiveFailures(2))}, []string{"", ""}},
+		{"policy interrupted", odd, []ops.Option{ops.WithFailurePolicy(ops.MaxConsecutiveFailures(2))}, []string{"", "t 0 7 29", "", "t 0 7 29", ""}},
+		{"middleware", always, []ops.Option{
+			ops.WithFailurePolicy(ops.MaxConsecutiveFailures(3)),
+			ops.WithMiddleware(ops.Timing(&captureLogger{}), ops.Validate(&captureLogger{})),
+		}, []string{"", "", ""}},
+	}
+
+	for _, d := range ds {
+		var out bytes.Buffer
+		l := &captureLogger{}
+
+		opts := append([]ops.Option{ops.WithInput(strings.NewReader(engineInput(5))), ops.WithOutput(&out)}, d.opts...)
+		o := ops.New("panicky", opts...)
+		o.Run(l, panicky(d.panics))
+
+		got := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")[1:]
+		if strings.Join(got, "|") != strings.Join(d.want, "|") {
+			t.Errorf("%s: got output %q, want %q", d.name, got, d.want)
+		}
+
+		panics := 0
+		for _, w := range d.want {
+			if w == "" {
+				panics++
+			}
+		}
+		if n := l.count("Turn Failed"); n != panics {
+			t.Errorf("%s: want %d failed turns logged, got %d", d.name, panics, n)
+		}
+	}
+}
+
+func TestStop(t *testing.T) {
+	o := ops.New("stopped", ops.WithInput(strings.NewReader(engineInput(1))), ops.WithOutput(&bytes.Buffer{}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			o.Stop()
+		}()
+	}
+	wg.Wait()
+
+	o.Wait()
+	o.Run(&captureLogger{}, ops.CommanderFunc(func(ops.Board, int) ops.CommandMessengers {
+		t.Error("want no turns run once stopped")
+		return nil
+	}))
+}
+
+func TestFailureLogging(t *testing.T) {
+	var buf bytes.Buffer
+	l := hlog.New(&buf, hlog.LevelInfo, hlog.Text)
+
+	o := ops.New("failing",
+		ops.WithInput(strings.NewReader(engineInput(1))),
+		ops.WithOutput(&bytes.Buffer{}),
+		ops.WithFailurePolicy(ops.MaxConsecutiveFailures(1)),
+	)
+	o.Run(l, ops.CommanderFunc(func(ops.Board, int) ops.CommandMessengers {
+		panic("failed")
+	}))
+
+	for _, want := range []string{`level=error turn=1 msg="Turn Failed" consecutive=1`, `level=error turn=1 msg=Stopping`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("want %q logged at info level, got:\n%s", want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,257 @@
+// Package opstest provides fixture boards and assertions for testing
+// Commander implementations.
+package opstest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daved/halitego/ops"
+)
+
+// Default attributes of built entities.
+const (
+	DefaultShipHealth   = 255
+	DefaultPlanetHealth = 2000
+	DefaultPlanetPorts  = 3
+	DefaultPlanetProd   = 0
+	DefaultPlanetRsrcs  = 1000
+)
+
+type ship struct {
+	id, owner      int
+	x, y, health   float64
+	velX, velY     float64
+	status         ops.ShipDockingStatus
+	planetID       int
+	docking, coold float64
+}
+
+type planet struct {
+	id                   int
+	x, y, radius, health float64
+	ports, prod, rsrcs   int
+	owner                int
+	owned                bool
+	shipIDs              []int
+}
+
+// ShipOption configures a ship added to a Builder.
+type ShipOption func(*ship)
+
+// ShipHealth sets the ship's health.
+func ShipHealth(h float64) ShipOption {
+	return func(s *ship) {
+		s.health = h
+	}
+}
+
+// ShipVelocity sets the ship's velocity. The engine always reports zero, so
+// boards given to a strategy.Commander carry velocities inferred from the
+// previous turn instead; pass consecutive boards to exercise that.
+func ShipVelocity(x, y float64) ShipOption {
+	return func(s *ship) {
+		s.velX, s.velY = x, y
+	}
+}
+
+// ShipCooldown sets the number of turns until the ship's weapon is ready.
+func ShipCooldown(c float64) ShipOption {
+	return func(s *ship) {
+		s.coold = c
+	}
+}
+
+// ShipStatus sets the ship's docking status and the planet it is docked
+// on. Ships which are not undocked are listed on the planet, which becomes
+// owned by the ship's owner.
+func ShipStatus(status ops.ShipDockingStatus, planetID int) ShipOption {
+	return func(s *ship) {
+		s.status, s.planetID = status, planetID
+	}
+}
+
+// DockedOn is shorthand for ShipStatus(ops.Docked, planetID).
+func DockedOn(planetID int) ShipOption {
+	return ShipStatus(ops.Docked, planetID)
+}
+
+// PlanetOption configures a planet added to a Builder.
+type PlanetOption func(*planet)
+
+// PlanetHealth sets the planet's health.
+func PlanetHealth(h float64) PlanetOption {
+	return func(p *planet) {
+		p.health = h
+	}
+}
+
+// PlanetPorts sets the planet's number of docking ports.
+func PlanetPorts(n int) PlanetOption {
+	return func(p *planet) {
+		p.ports = n
+	}
+}
+
+// PlanetProduction sets the planet's production rate.
+func PlanetProduction(n int) PlanetOption {
+	return func(p *planet) {
+		p.prod = n
+	}
+}
+
+// PlanetResources sets the planet's remaining resources.
+func PlanetResources(n int) PlanetOption {
+	return func(p *planet) {
+		p.rsrcs = n
+	}
+}
+
+// PlanetOwner sets the planet's owner regardless of docked ships.
+func PlanetOwner(player int) PlanetOption {
+	return func(p *planet) {
+		p.owner, p.owned = player, true
+	}
+}
+
+// Builder fluently describes a Board. Planet and ship IDs are each assigned
+// in the order of addition starting at zero.
+type Builder struct {
+	xLen, yLen int
+	playerCt   int
+	ss         []*ship
+	ps         []*planet
+}
+
+// NewBoard returns a Builder of an empty board with the provided dimensions
+// and number of players.
+func NewBoard(xLen, yLen, playerCt int) *Builder {
+	return &Builder{
+		xLen:     xLen,
+		yLen:     yLen,
+		playerCt: playerCt,
+	}
+}
+
+// Planet adds a planet.
+func (b *Builder) Planet(x, y, radius float64, opts ...PlanetOption) *Builder {
+	p := &planet{
+		id:     len(b.ps),
+		x:      x,
+		y:      y,
+		radius: radius,
+		health: DefaultPlanetHealth,
+		ports:  DefaultPlanetPorts,
+		prod:   DefaultPlanetProd,
+		rsrcs:  DefaultPlanetRsrcs,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	b.ps = append(b.ps, p)
+
+	return b
+}
+
+// Ship adds a ship owned by "owner". It panics if "owner" is not one of the
+// board's players.
+func (b *Builder) Ship(owner int, x, y float64, opts ...ShipOption) *Builder {
+	if owner < 0 || owner >= b.playerCt {
+		panic(fmt.Sprintf("ship owner %d is not one of %d players", owner, b.playerCt))
+	}
+
+	s := &ship{
+		id:     len(b.ss),
+		owner:  owner,
+		x:      x,
+		y:      y,
+		health: DefaultShipHealth,
+		status: ops.Undocked,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	b.ss = append(b.ss, s)
+
+	return b
+}
+
+// Dimensions returns the board dimensions.
+func (b *Builder) Dimensions() (int, int) {
+	return b.xLen, b.yLen
+}
+
+// Line returns the board as a line of game state as sent by the game
+// engine.
+func (b *Builder) Line() string {
+	ps := make([]planet, len(b.ps))
+	for k, p := range b.ps {
+		ps[k] = *p
+		ps[k].shipIDs = nil
+	}
+
+	for _, s := range b.ss {
+		if s.status == ops.Undocked || s.planetID < 0 || s.planetID >= len(ps) {
+			continue
+		}
+
+		p := &ps[s.planetID]
+		p.shipIDs = append(p.shipIDs, s.id)
+		if !p.owned {
+			p.owner, p.owned = s.owner, true
+		}
+	}
+
+	var ts []string
+	add := func(vs ...interface{}) {
+		for _, v := range vs {
+			ts = append(ts, fmt.Sprint(v))
+		}
+	}
+
+	add(b.playerCt)
+	for id := 0; id < b.playerCt; id++ {
+		var ss []*ship
+		for _, s := range b.ss {
+			if s.owner == id {
+				ss = append(ss, s)
+			}
+		}
+
+		add(id, len(ss))
+		for _, s := range ss {
+			add(s.id, s.x, s.y, s.health, s.velX, s.velY,
+				int(s.status), s.planetID, s.docking, s.coold)
+		}
+	}
+
+	add(len(ps))
+	for _, p := range ps {
+		owned := 0
+		if p.owned {
+			owned = 1
+		}
+
+		add(p.id, p.x, p.y, p.health, p.radius, p.ports, p.prod, p.rsrcs,
+			owned, p.owner, len(p.shipIDs))
+		for _, id := range p.shipIDs {
+			add(id)
+		}
+	}
+
+	return strings.Join(ts, " ")
+}
+
+// Board returns the described Board.
+func (b *Builder) Board() ops.Board {
+	ob, err := ops.ParseBoard(b.xLen, b.yLen, b.Line())
+	if err != nil {
+		panic(err)
+	}
+
+	return ob
+}
@@ -0,0 +1,37 @@
+package opstest
+
+import (
+	"testing"
+
+	"github.com/daved/halitego/ops"
+)
+
+func TestBuilderBoard(t *testing.T) {
+	b := NewBoard(240, 160, 2).
+		Planet(50, 50, 5, PlanetPorts(2)).
+		Planet(150, 100, 8).
+		Ship(0, 10, 10).
+		Ship(1, 200, 150, DockedOn(1)).
+		Ship(1, 201, 150, ShipStatus(ops.Docking, 1), ShipHealth(100)).
+		Board()
+
+	if got := len(b.Ships()); got != 2 {
+		t.Fatalf("want 2 players, got %d", got)
+	}
+	if got := len(b.Ships()[1]); got != 2 {
+		t.Fatalf("want 2 ships of player 1, got %d", got)
+	}
+
+	s := b.Ships()[1][1]
+	if s.ID() != 2 || s.DockingStatus() != ops.Docking || s.PlanetID() != 1 || s.Health() != 100 {
+		t.Errorf("unexpected ship %+v", s)
+	}
+
+	p0, p1 := b.Planets()[0], b.Planets()[1]
+	if p0.Owned() || p0.PortCt() != 2 {
+		t.Errorf("unexpected planet %+v", p0)
+	}
+	if !p1.Owned() || p1.Owner() != 1 || p1.DockedCt() != 2 || p1.FreePorts() != 1 {
+		t.Errorf("unexpected planet %+v", p1)
+	}
+}
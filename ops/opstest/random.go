@@ -0,0 +1,85 @@
+package opstest
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/daved/halitego/ops"
+)
+
+// randomTries bounds the attempts made to place an entity clear of planets.
+const randomTries = 64
+
+// RandomBoard returns a Builder of a board with "planetCt" planets and
+// "shipCt" ships per player placed using "rng". Each player owns some
+// planets, on which about a quarter of their ships are docked. It resembles
+// the late game and is intended for benchmarks.
+func RandomBoard(rng *rand.Rand, xLen, yLen, playerCt, shipCt, planetCt int) *Builder {
+	b := NewBoard(xLen, yLen, playerCt)
+
+	type circle struct{ x, y, r float64 }
+	var cs []circle
+
+	clear := func(x, y, r float64) bool {
+		for _, c := range cs {
+			if math.Hypot(c.x-x, c.y-y) < c.r+r+2 {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	place := func(r float64) (float64, float64) {
+		var x, y float64
+		for i := 0; i < randomTries; i++ {
+			x = r + rng.Float64()*(float64(xLen)-2*r)
+			y = r + rng.Float64()*(float64(yLen)-2*r)
+			if clear(x, y, r) {
+				break
+			}
+		}
+
+		return x, y
+	}
+
+	for i := 0; i < planetCt; i++ {
+		r := 3 + rng.Float64()*9
+		x, y := place(r)
+		ports := 2 + rng.Intn(5)
+
+		b.Planet(x, y, r, PlanetPorts(ports), PlanetProduction(6))
+		cs = append(cs, circle{x, y, r})
+	}
+
+	docked := make([]int, planetCt)
+	for id := 0; id < playerCt; id++ {
+		for i := 0; i < shipCt; i++ {
+			// planets are owned round robin by player
+			pid := id + playerCt*rng.Intn(planetCt/playerCt+1)
+			if i%4 == 0 && pid < planetCt && docked[pid] < b.ps[pid].ports {
+				p := b.ps[pid]
+				ang := rng.Float64() * 2 * math.Pi
+				x, y := p.x+(p.radius+0.5)*math.Cos(ang), p.y+(p.radius+0.5)*math.Sin(ang)
+
+				b.Ship(id, x, y, DockedOn(pid))
+				docked[pid]++
+				continue
+			}
+
+			x, y := place(0.5)
+			vx, vy := rng.Float64()*14-7, rng.Float64()*14-7
+
+			b.Ship(id, x, y, ShipVelocity(vx, vy), ShipHealth(float64(1+rng.Intn(255))))
+			cs = append(cs, circle{x, y, 0.5})
+		}
+	}
+
+	return b
+}
+
+// LateGame returns a random four player board with 440 ships and 28
+// planets.
+func LateGame(seed int64) ops.Board {
+	return RandomBoard(rand.New(rand.NewSource(seed)), 384, 256, 4, 110, 28).Board()
+}
@@ -0,0 +1,235 @@
+package opstest
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/daved/halitego/geom"
+	"github.com/daved/halitego/ops"
+	"github.com/daved/halitego/ops/internal/msg"
+)
+
+// Kind identifies the type of a Command.
+type Kind int
+
+// Kind values.
+const (
+	Thrust Kind = iota + 1
+	Dock
+	Undock
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Thrust:
+		return "thrust"
+	case Dock:
+		return "dock"
+	case Undock:
+		return "undock"
+	default:
+		return "unknown"
+	}
+}
+
+// Command is a ship's command decoded from a Commander's messages.
+type Command struct {
+	Kind      Kind
+	ShipID    int
+	PlanetID  int
+	Magnitude int
+	Direction int
+}
+
+func (c Command) String() string {
+	switch c.Kind {
+	case Thrust:
+		return fmt.Sprintf("ship %d thrusts %d at %d degrees", c.ShipID, c.Magnitude, c.Direction)
+	case Dock:
+		return fmt.Sprintf("ship %d docks on planet %d", c.ShipID, c.PlanetID)
+	default:
+		return fmt.Sprintf("ship %d %ss", c.ShipID, c.Kind)
+	}
+}
+
+// Result holds the commands issued by a Commander during one turn.
+type Result struct {
+	Board ops.Board
+	ID    int
+	ms    ops.CommandMessengers
+	cs    []Command
+}
+
+// Run commands player "id" for one turn of "b" using "c".
+func Run(c ops.Commander, b ops.Board, id int) *Result {
+	ms := c.Command(b, id)
+
+	r := &Result{
+		Board: b,
+		ID:    id,
+		ms:    ms,
+	}
+
+	for _, m := range ms {
+		switch v := m.(type) {
+		case msg.Thrust:
+			r.cs = append(r.cs, Command{Kind: Thrust, ShipID: v.ID(), Magnitude: v.Magnitude(), Direction: v.Direction()})
+		case msg.Dock:
+			r.cs = append(r.cs, Command{Kind: Dock, ShipID: v.ID(), PlanetID: v.PlanetID()})
+		case msg.Undock:
+			r.cs = append(r.cs, Command{Kind: Undock, ShipID: v.ID()})
+		}
+	}
+
+	return r
+}
+
+// Message returns the message which would be sent to the game engine.
+func (r *Result) Message() string {
+	return msg.Messengers(r.ms).Message()
+}
+
+// Commands returns all commands other than no-ops.
+func (r *Result) Commands() []Command {
+	return r.cs
+}
+
+// Command returns the command issued to ship "shipID", if any.
+func (r *Result) Command(shipID int) (Command, bool) {
+	for _, c := range r.cs {
+		if c.ShipID == shipID {
+			return c, true
+		}
+	}
+
+	return Command{}, false
+}
+
+// AssertDocks fails the test unless ship "shipID" docks on planet
+// "planetID".
+func (r *Result) AssertDocks(t testing.TB, shipID, planetID int) {
+	t.Helper()
+
+	c, ok := r.Command(shipID)
+	if !ok || c.Kind != Dock || c.PlanetID != planetID {
+		t.Errorf("want ship %d docks on planet %d, got %s", shipID, planetID, r.describe(shipID))
+	}
+}
+
+// AssertThrusts fails the test unless ship "shipID" thrusts.
+func (r *Result) AssertThrusts(t testing.TB, shipID int) {
+	t.Helper()
+
+	c, ok := r.Command(shipID)
+	if !ok || c.Kind != Thrust || c.Magnitude == 0 {
+		t.Errorf("want ship %d thrusts, got %s", shipID, r.describe(shipID))
+	}
+}
+
+// AssertThrustsToward fails the test unless ship "shipID" thrusts in a
+// direction within "tolerance" degrees of the direction to "l".
+func (r *Result) AssertThrustsToward(t testing.TB, shipID int, l geom.Locator, tolerance int) {
+	t.Helper()
+
+	c, ok := r.Command(shipID)
+	s, sok := r.ship(shipID)
+	if !ok || !sok || c.Kind != Thrust || c.Magnitude == 0 {
+		t.Errorf("want ship %d thrusts, got %s", shipID, r.describe(shipID))
+		return
+	}
+
+	d := c.Direction - geom.BoundDegrees(l, s)
+	d = (d%360 + 360) % 360
+	if d > 180 {
+		d = 360 - d
+	}
+
+	if d > tolerance {
+		x, y := l.Coords()
+		t.Errorf("want ship %d thrusts toward (%.2f, %.2f), got %s (%d degrees off)", shipID, x, y, c, d)
+	}
+}
+
+// AssertUndocks fails the test unless ship "shipID" undocks.
+func (r *Result) AssertUndocks(t testing.TB, shipID int) {
+	t.Helper()
+
+	c, ok := r.Command(shipID)
+	if !ok || c.Kind != Undock {
+		t.Errorf("want ship %d undocks, got %s", shipID, r.describe(shipID))
+	}
+}
+
+// AssertNoOp fails the test if ship "shipID" is commanded.
+func (r *Result) AssertNoOp(t testing.TB, shipID int) {
+	t.Helper()
+
+	if _, ok := r.Command(shipID); ok {
+		t.Errorf("want ship %d is not commanded, got %s", shipID, r.describe(shipID))
+	}
+}
+
+// AssertOwnShips fails the test if any command is issued to a ship which is
+// not owned by the player.
+func (r *Result) AssertOwnShips(t testing.TB) {
+	t.Helper()
+
+	own := make(map[int]bool)
+	for _, s := range r.Board.Ships()[r.ID] {
+		own[s.ID()] = true
+	}
+
+	for _, c := range r.cs {
+		if !own[c.ShipID] {
+			t.Errorf("want only own ships are commanded, got %s", c)
+		}
+	}
+}
+
+// AssertOnMap fails the test if any ship thrusts to a destination outside
+// of the board.
+func (r *Result) AssertOnMap(t testing.TB) {
+	t.Helper()
+
+	ss := make(map[int]ops.Ship)
+	for _, s := range r.Board.Ships()[r.ID] {
+		ss[s.ID()] = s
+	}
+
+	xLen, yLen := r.Board.Dimensions()
+
+	for _, c := range r.cs {
+		s, ok := ss[c.ShipID]
+		if c.Kind != Thrust || !ok {
+			continue
+		}
+
+		x, y := s.Coords()
+		rad := float64(c.Direction) * math.Pi / 180
+		x += float64(c.Magnitude) * math.Cos(rad)
+		y += float64(c.Magnitude) * math.Sin(rad)
+
+		if x < 0 || y < 0 || x >= float64(xLen) || y >= float64(yLen) {
+			t.Errorf("want thrusts stay on the map, got %s to (%.2f, %.2f)", c, x, y)
+		}
+	}
+}
+
+func (r *Result) ship(shipID int) (ops.Ship, bool) {
+	for _, s := range r.Board.Ships()[r.ID] {
+		if s.ID() == shipID {
+			return s, true
+		}
+	}
+
+	return ops.Ship{}, false
+}
+
+func (r *Result) describe(shipID int) string {
+	if c, ok := r.Command(shipID); ok {
+		return c.String()
+	}
+
+	return fmt.Sprintf("ship %d is not commanded", shipID)
+}
@@ -0,0 +1,78 @@
+package ops
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// CommandShips calls "fn" for each ship using up to "workers" goroutines and
+// returns the results in the order of "ss", so that the resulting message is
+// deterministic. "fn" must not modify state shared between ships. A panic
+// within "fn" is raised again on the calling goroutine once all workers have
+// finished, so that it may be recovered by the caller.
+func CommandShips(ss []Ship, workers int, fn func(Ship) CommandMessenger) CommandMessengers {
+	ms := make(CommandMessengers, len(ss))
+
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(ss) {
+		workers = len(ss)
+	}
+
+	idx := make(chan int)
+
+	var (
+		wg   sync.WaitGroup
+		once sync.Once
+		perr *workerPanic
+	)
+
+	call := func(k int) {
+		defer func() {
+			if r := recover(); r != nil {
+				once.Do(func() {
+					perr = &workerPanic{ship: ss[k].id, val: r, stack: debug.Stack()}
+				})
+			}
+		}()
+
+		ms[k] = fn(ss[k])
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for k := range idx {
+				call(k)
+			}
+		}()
+	}
+
+	for k := range ss {
+		idx <- k
+	}
+	close(idx)
+	wg.Wait()
+
+	if perr != nil {
+		panic(perr)
+	}
+
+	return ms
+}
+
+// workerPanic holds a panic raised while commanding a ship on a worker
+// goroutine, along with the stack of that goroutine.
+type workerPanic struct {
+	ship  int
+	val   interface{}
+	stack []byte
+}
+
+func (p *workerPanic) String() string {
+	return fmt.Sprintf("ship %d: %v\n%s", p.ship, p.val, p.stack)
+}
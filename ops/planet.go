@@ -13,38 +13,74 @@ type Planet struct {
 	owned    float64
 }
 
-// makePlanet from a slice of game state tokens
-func makePlanet(tokens []string) (Planet, []string) {
-	p := Planet{
-		Entity: Entity{
-			Location: geom.MakeLocation(
-				readTokenFloat(tokens, 1),
-				readTokenFloat(tokens, 2),
-				readTokenFloat(tokens, 4),
-			),
-			id:     readTokenInt(tokens, 0),
-			health: readTokenFloat(tokens, 3),
-			owner:  readTokenInt(tokens, 9),
-		},
-		portCt:   readTokenFloat(tokens, 5),
-		dockedCt: readTokenFloat(tokens, 10),
-		prodRate: readTokenFloat(tokens, 6),
-		rsrcs:    readTokenFloat(tokens, 7),
-		owned:    readTokenFloat(tokens, 8),
-	}
+// makePlanet from game state tokens
+func makePlanet(r *tokenReader) (Planet, error) {
+	var (
+		p         Planet
+		x, y, rad float64
+		shipCt    int
+		err       error
+	)
 
-	shipCt := int(p.dockedCt)
+	if p.id, err = r.int(); err != nil {
+		return p, err
+	}
+	if err = r.floats(&x, &y, &p.health, &rad, &p.portCt, &p.prodRate, &p.rsrcs, &p.owned); err != nil {
+		return p, err
+	}
+	if p.owner, err = r.int(); err != nil {
+		return p, err
+	}
+	if shipCt, err = r.count(); err != nil {
+		return p, err
+	}
 
 	for i := 0; i < shipCt; i++ {
-		shipID := readTokenInt(tokens, 11+i)
+		shipID, err := r.int()
+		if err != nil {
+			return p, err
+		}
 
 		p.shipIDs = append(p.shipIDs, shipID)
 	}
 
-	return p, tokens[11+shipCt:]
+	p.dockedCt = float64(shipCt)
+	p.Location = geom.MakeLocation(x, y, rad)
+
+	return p, nil
 }
 
 // Owned ...
 func (p Planet) Owned() bool {
 	return p.owned > 0
 }
+
+// FreePorts returns the number of ports not occupied by docked ships.
+func (p Planet) FreePorts() int {
+	return int(p.portCt - p.dockedCt)
+}
+
+// PortCt returns the number of docking ports.
+func (p Planet) PortCt() int {
+	return int(p.portCt)
+}
+
+// DockedCt returns the number of ships docked.
+func (p Planet) DockedCt() int {
+	return int(p.dockedCt)
+}
+
+// ProdRate returns the current production rate.
+func (p Planet) ProdRate() float64 {
+	return p.prodRate
+}
+
+// Resources returns the remaining resources.
+func (p Planet) Resources() float64 {
+	return p.rsrcs
+}
+
+// ShipIDs returns the IDs of the ships docked.
+func (p Planet) ShipIDs() []int {
+	return p.shipIDs
+}
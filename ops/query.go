@@ -0,0 +1,293 @@
+package ops
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/daved/halitego/geom"
+)
+
+// Filter reports whether an item should be retained by a Query.
+type Filter[T any] func(T) bool
+
+// Score assigns a value to an item by which a Query is able to be ordered.
+// Lower scores are ordered first.
+type Score[T any] func(T) float64
+
+// Query is a composable selection over a set of planets or ships. Queries are
+// immutable; each method returns a new Query.
+type Query[T any] struct {
+	items []T
+}
+
+// NewQuery returns a Query over the provided items.
+func NewQuery[T any](items []T) Query[T] {
+	return Query[T]{items: items}
+}
+
+// QueryPlanets returns a Query over all planets on the Board.
+func QueryPlanets(b Board) Query[Planet] {
+	return NewQuery(b.Planets())
+}
+
+// QueryShips returns a Query over all ships on the Board.
+func QueryShips(b Board) Query[Ship] {
+	var ss []Ship
+	for _, g := range b.Ships() {
+		ss = append(ss, g...)
+	}
+
+	return NewQuery(ss)
+}
+
+// Where retains the items which satisfy all provided filters.
+func (q Query[T]) Where(fs ...Filter[T]) Query[T] {
+	var items []T
+
+outer:
+	for _, v := range q.items {
+		for _, f := range fs {
+			if !f(v) {
+				continue outer
+			}
+		}
+
+		items = append(items, v)
+	}
+
+	return Query[T]{items: items}
+}
+
+// OrderBy orders the items by score from lowest to highest. Items with equal
+// scores retain their relative order.
+func (q Query[T]) OrderBy(s Score[T]) Query[T] {
+	scs := makeScoreds(q.items, s)
+	sort.Stable(scs)
+
+	return Query[T]{items: scs.items()}
+}
+
+// Nearest retains the "k" items with the lowest scores ordered from lowest
+// to highest. Only the retained items are sorted.
+func (q Query[T]) Nearest(k int, s Score[T]) Query[T] {
+	if k <= 0 {
+		return Query[T]{}
+	}
+
+	h := &scoredHeap[T]{}
+	for i, v := range q.items {
+		sc := scored[T]{item: v, score: s(v), idx: i}
+
+		if h.Len() < k {
+			heap.Push(h, sc)
+			continue
+		}
+
+		if sc.less((*h)[0]) {
+			(*h)[0] = sc
+			heap.Fix(h, 0)
+		}
+	}
+
+	scs := scoreds[T](*h)
+	sort.Sort(scs)
+
+	return Query[T]{items: scs.items()}
+}
+
+// All returns the selected items.
+func (q Query[T]) All() []T {
+	return q.items
+}
+
+// First returns the first selected item, if any.
+func (q Query[T]) First() (T, bool) {
+	if len(q.items) == 0 {
+		var t T
+		return t, false
+	}
+
+	return q.items[0], true
+}
+
+// Len returns the number of selected items.
+func (q Query[T]) Len() int {
+	return len(q.items)
+}
+
+// Not inverts a Filter.
+func Not[T any](f Filter[T]) Filter[T] {
+	return func(v T) bool {
+		return !f(v)
+	}
+}
+
+// Desc inverts a Score so that items are ordered from highest to lowest.
+func Desc[T any](s Score[T]) Score[T] {
+	return func(v T) float64 {
+		return -s(v)
+	}
+}
+
+// PlanetOwnedBy retains planets owned by the player with the provided ID.
+func PlanetOwnedBy(id int) Filter[Planet] {
+	return func(p Planet) bool {
+		return p.Owned() && p.Owner() == id
+	}
+}
+
+// PlanetUnowned retains planets which are not owned.
+func PlanetUnowned() Filter[Planet] {
+	return func(p Planet) bool {
+		return !p.Owned()
+	}
+}
+
+// PlanetFreePorts retains planets with at least "min" free ports.
+func PlanetFreePorts(min int) Filter[Planet] {
+	return func(p Planet) bool {
+		return p.FreePorts() >= min
+	}
+}
+
+// PlanetMinHealth retains planets with at least "min" health.
+func PlanetMinHealth(min float64) Filter[Planet] {
+	return func(p Planet) bool {
+		return p.Health() >= min
+	}
+}
+
+// PlanetEdgeDistance scores planets by their edge distance to the Marker.
+func PlanetEdgeDistance(m geom.Marker) Score[Planet] {
+	return func(p Planet) float64 {
+		return geom.EdgeDistance(m, p)
+	}
+}
+
+// PlanetProdRate scores planets by their production rate.
+func PlanetProdRate(p Planet) float64 {
+	return p.prodRate
+}
+
+// PlanetResources scores planets by their remaining resources.
+func PlanetResources(p Planet) float64 {
+	return p.rsrcs
+}
+
+// PlanetPorts scores planets by their number of free ports.
+func PlanetPorts(p Planet) float64 {
+	return float64(p.FreePorts())
+}
+
+// ShipOwnedBy retains ships owned by the player with the provided ID.
+func ShipOwnedBy(id int) Filter[Ship] {
+	return func(s Ship) bool {
+		return s.Owner() == id
+	}
+}
+
+// ShipStatus retains ships with any of the provided docking statuses.
+func ShipStatus(ss ...ShipDockingStatus) Filter[Ship] {
+	return func(s Ship) bool {
+		for _, v := range ss {
+			if s.sdStatus == v {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// ShipMinHealth retains ships with at least "min" health.
+func ShipMinHealth(min float64) Filter[Ship] {
+	return func(s Ship) bool {
+		return s.Health() >= min
+	}
+}
+
+// ShipEdgeDistance scores ships by their edge distance to the Marker.
+func ShipEdgeDistance(m geom.Marker) Score[Ship] {
+	return func(s Ship) float64 {
+		return geom.EdgeDistance(m, s)
+	}
+}
+
+// ShipHealth scores ships by their health.
+func ShipHealth(s Ship) float64 {
+	return s.Health()
+}
+
+type scored[T any] struct {
+	item  T
+	score float64
+	idx   int
+}
+
+func (s scored[T]) less(o scored[T]) bool {
+	if s.score == o.score {
+		return s.idx < o.idx
+	}
+
+	return s.score < o.score
+}
+
+type scoreds[T any] []scored[T]
+
+func makeScoreds[T any](items []T, s Score[T]) scoreds[T] {
+	scs := make(scoreds[T], 0, len(items))
+	for i, v := range items {
+		scs = append(scs, scored[T]{item: v, score: s(v), idx: i})
+	}
+
+	return scs
+}
+
+func (ss scoreds[T]) items() []T {
+	var items []T
+	for _, s := range ss {
+		items = append(items, s.item)
+	}
+
+	return items
+}
+
+func (ss scoreds[T]) Len() int {
+	return len(ss)
+}
+
+func (ss scoreds[T]) Swap(i, j int) {
+	ss[i], ss[j] = ss[j], ss[i]
+}
+
+func (ss scoreds[T]) Less(i, j int) bool {
+	return ss[i].less(ss[j])
+}
+
+// scoredHeap is a max-heap used to retain the lowest scored items.
+type scoredHeap[T any] []scored[T]
+
+func (h scoredHeap[T]) Len() int {
+	return len(h)
+}
+
+func (h scoredHeap[T]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h scoredHeap[T]) Less(i, j int) bool {
+	return h[j].less(h[i])
+}
+
+func (h *scoredHeap[T]) Push(x interface{}) {
+	*h = append(*h, x.(scored[T]))
+}
+
+func (h *scoredHeap[T]) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+
+	return x
+}
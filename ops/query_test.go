@@ -0,0 +1,89 @@
+package ops_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/daved/halitego/geom"
+	"github.com/daved/halitego/ops"
+	"github.com/daved/halitego/ops/opstest"
+)
+
+type item struct {
+	name  string
+	score float64
+}
+
+func TestQuery(t *testing.T) {
+	items := []item{
+		{"a", 3}, {"b", 1}, {"c", 2}, {"d", 1}, {"e", 5}, {"f", 2},
+	}
+
+	score := func(v item) float64 { return v.score }
+	odd := func(v item) bool { return int(v.score)%2 == 1 }
+	notE := func(v item) bool { return v.name != "e" }
+
+	ds := []struct {
+		name string
+		q    ops.Query[item]
+		want string
+	}{
+		{"all", ops.NewQuery(items), "abcdef"},
+		{"empty", ops.NewQuery[item](nil), ""},
+		{"where", ops.NewQuery(items).Where(odd), "abde"},
+		{"where all of", ops.NewQuery(items).Where(odd, notE), "abd"},
+		{"where none", ops.NewQuery(items).Where(), "abcdef"},
+		{"not", ops.NewQuery(items).Where(ops.Not[item](odd)), "cf"},
+		{"order ties stable", ops.NewQuery(items).OrderBy(score), "bdcfae"},
+		{"desc ties stable", ops.NewQuery(items).OrderBy(ops.Desc(score)), "eacfbd"},
+		{"nearest", ops.NewQuery(items).Nearest(3, score), "bdc"},
+		{"nearest ties", ops.NewQuery(items).Nearest(4, score), "bdcf"},
+		{"nearest cut tie", ops.NewQuery(items).Nearest(1, score), "b"},
+		{"nearest k > len", ops.NewQuery(items).Nearest(10, score), "bdcfae"},
+		{"nearest k = 0", ops.NewQuery(items).Nearest(0, score), ""},
+		{"nearest desc", ops.NewQuery(items).Nearest(2, ops.Desc(score)), "ea"},
+		{"where then order", ops.NewQuery(items).Where(odd).OrderBy(ops.Desc(score)), "eabd"},
+	}
+
+	for _, d := range ds {
+		got := ""
+		for _, v := range d.q.All() {
+			got += v.name
+		}
+
+		if got != d.want {
+			t.Errorf("%s: got %q, want %q", d.name, got, d.want)
+		}
+		if d.q.Len() != len(d.want) {
+			t.Errorf("%s: got len %d, want %d", d.name, d.q.Len(), len(d.want))
+		}
+
+		first, ok := d.q.First()
+		if ok != (d.want != "") || (ok && first.name != d.want[:1]) {
+			t.Errorf("%s: got first %v (%v), want %q", d.name, first, ok, d.want)
+		}
+	}
+
+	if items[0].name != "a" || items[1].name != "b" {
+		t.Errorf("want source items unmodified, got %v", items)
+	}
+}
+
+func TestPlanetsByProximity(t *testing.T) {
+	b := opstest.NewBoard(240, 160, 1).
+		Planet(100, 50, 5).
+		Planet(50, 50, 10).
+		Planet(50, 100, 5).
+		Planet(50, 10, 5).
+		Board()
+
+	var got []int
+	for _, p := range ops.PlanetsByProximity(b, geom.MakeLocation(50, 75, 0)) {
+		got = append(got, p.ID())
+	}
+
+	// planets 1 and 2 are both 15 from the location's edge
+	if want := []int{1, 2, 0, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,125 @@
+package ops
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Recording line prefixes.
+const (
+	recIn      = "< "
+	recOut     = "> "
+	recComment = "# "
+)
+
+// Recording holds the lines exchanged with the game engine during a game as
+// written by WithRecorder. Lines beginning with "# " are kept as comments and
+// may be used to note details such as the seed of the recorded bot.
+type Recording struct {
+	in       []string
+	out      []string
+	comments []string
+}
+
+// ReadRecording ...
+func ReadRecording(r io.Reader) (*Recording, error) {
+	rec := &Recording{}
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for n := 1; sc.Scan(); n++ {
+		l := sc.Text()
+
+		switch {
+		case strings.HasPrefix(l, recIn):
+			rec.in = append(rec.in, l[len(recIn):])
+		case strings.HasPrefix(l, recOut):
+			rec.out = append(rec.out, l[len(recOut):])
+		case strings.HasPrefix(l, recComment):
+			rec.comments = append(rec.comments, l[len(recComment):])
+		case strings.TrimSpace(l) == "":
+		default:
+			return nil, fmt.Errorf("recording line %d: unknown prefix", n)
+		}
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(rec.in) < 3 || len(rec.out) < 1 {
+		return nil, fmt.Errorf("recording is incomplete")
+	}
+
+	return rec, nil
+}
+
+// Comments returns the comment lines of the recording.
+func (rec *Recording) Comments() []string {
+	return rec.comments
+}
+
+// BotName returns the name sent by the recorded bot.
+func (rec *Recording) BotName() string {
+	return rec.out[0]
+}
+
+// Turns returns the number of recorded turns.
+func (rec *Recording) Turns() int {
+	return len(rec.in) - 3
+}
+
+// Operations returns Operations which read the recorded input and write to
+// "w". The recorded initial board is read, as with New.
+func (rec *Recording) Operations(w io.Writer, opts ...Option) *Operations {
+	in := strings.Join(rec.in, "\n") + "\n"
+	opts = append([]Option{WithInput(strings.NewReader(in)), WithOutput(w)}, opts...)
+
+	return New(rec.BotName(), opts...)
+}
+
+// Board returns the board of the provided turn. Turn 0 is the initial board.
+func (rec *Recording) Board(turn int) (Board, error) {
+	if turn < 0 || turn > rec.Turns() {
+		return Board{}, fmt.Errorf("turn %d out of range (0-%d)", turn, rec.Turns())
+	}
+
+	var x, y int
+	if _, err := fmt.Sscanf(rec.in[1], "%d %d", &x, &y); err != nil {
+		return Board{}, fmt.Errorf("bad dimensions: %v", err)
+	}
+
+	return ParseBoard(x, y, rec.in[2+turn])
+}
+
+// Verify replays the recorded input into the Commander returned by "newC"
+// and reports the first turn on which its output differs from the recorded
+// output. Commanders must be deterministic for the output to match, and the
+// options (e.g. middleware) should match those used while recording.
+func (rec *Recording) Verify(l Logger, newC func(initialBoard Board) Commander, opts ...Option) error {
+	var buf bytes.Buffer
+	o := rec.Operations(&buf, opts...)
+	c := Chain(newC(o.InitialBoard()), o.mws...)
+
+	for i := 1; i <= rec.Turns(); i++ {
+		o.runIteration(l, i, c)
+	}
+
+	got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+
+	for k, want := range rec.out {
+		if k >= len(got) {
+			return fmt.Errorf("turn %d: no output, want %q", k, want)
+		}
+
+		if got[k] != want {
+			return fmt.Errorf("turn %d: got %q, want %q", k, got[k], want)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package ops_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/daved/halitego/geom"
+	"github.com/daved/halitego/ops"
+	"github.com/daved/halitego/ops/opstest"
+)
+
+func TestRecording(t *testing.T) {
+	l := log.New(ioutil.Discard, "", 0)
+
+	var bs []*opstest.Builder
+	for turn := 0; turn <= 3; turn++ {
+		x := 10 + 7*float64(turn)
+		bs = append(bs, opstest.NewBoard(240, 160, 2).
+			Planet(120, 80, 10).
+			Ship(0, x, 20).
+			Ship(0, x, 40).
+			Ship(1, 200, 140))
+	}
+
+	x, y := bs[0].Dimensions()
+	in := fmt.Sprintf("0\n%d %d\n", x, y)
+	for _, b := range bs {
+		in += b.Line() + "\n"
+	}
+
+	newC := func(ops.Board) ops.Commander {
+		return ops.CommanderFunc(func(b ops.Board, id int) ops.CommandMessengers {
+			var ms ops.CommandMessengers
+			for _, s := range b.Ships()[id] {
+				ms = append(ms, s.Navigate(geom.MakeLocation(120, 80, 0)))
+			}
+
+			return ms
+		})
+	}
+
+	var out, rec bytes.Buffer
+	fmt.Fprintln(&rec, "# seed 42")
+
+	o := ops.New("recorded", ops.WithInput(strings.NewReader(in)), ops.WithOutput(&out), ops.WithRecorder(&rec))
+	o.Run(l, newC(o.InitialBoard()))
+
+	r, err := ops.ReadRecording(bytes.NewReader(rec.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.BotName() != "recorded" || r.Turns() != 3 {
+		t.Errorf("want 3 turns by recorded, got %d by %q", r.Turns(), r.BotName())
+	}
+	if cs := r.Comments(); len(cs) != 1 || cs[0] != "seed 42" {
+		t.Errorf("want seed comment, got %q", cs)
+	}
+
+	b, err := r.Board(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wb := bs[2].Board()
+	if got, want := b.Ships()[0][0], wb.Ships()[0][0]; got != want {
+		t.Errorf("want turn 2 ship %v, got %v", want, got)
+	}
+	if _, err := r.Board(4); err == nil {
+		t.Errorf("want error for turn beyond recording")
+	}
+
+	if err := r.Verify(l, newC); err != nil {
+		t.Errorf("want recording verified, got %v", err)
+	}
+
+	lines := strings.Split(rec.String(), "\n")
+	outs := 0
+	for k, line := range lines {
+		if !strings.HasPrefix(line, "> ") {
+			continue
+		}
+
+		if outs == 2 {
+			lines[k] = "> t 0 7 0"
+		}
+		outs++
+	}
+
+	r, err = ops.ReadRecording(strings.NewReader(strings.Join(lines, "\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.Verify(l, newC)
+	if err == nil || !strings.HasPrefix(err.Error(), "turn 2:") {
+		t.Errorf("want turn 2 to differ, got %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package ops
+
+import "github.com/daved/halitego/ops/internal/msg"
+
+// DockReservations tracks the ports claimed by dock commands issued during a
+// single turn so that multiple ships never contend for the last free port.
+// Ports held by undocking ships stay occupied until the undocking completes,
+// so they are never available to be claimed.
+type DockReservations struct {
+	b      Board
+	claims map[int]int
+	ships  map[int]int
+}
+
+// NewDockReservations returns a DockReservations scoped to the turn described
+// by the provided Board.
+func NewDockReservations(b Board) *DockReservations {
+	return &DockReservations{
+		b:      b,
+		claims: make(map[int]int),
+		ships:  make(map[int]int),
+	}
+}
+
+// Dock validates the docking of ship "s" on planet "p" while accounting for
+// ports already claimed this turn. A port is claimed for the ship on success,
+// replacing any port claimed for the ship on another planet. A ship which
+// fails to dock keeps its existing claim.
+func (r *DockReservations) Dock(s Ship, p Planet) (msg.Dock, error) {
+	pid, ok := r.ships[s.id]
+	if ok && pid == p.id {
+		return msg.MakeDock(s.id, p.id), nil
+	}
+
+	m, err := s.dock(p, r.claims[p.id])
+	if err != nil {
+		return m, err
+	}
+
+	r.Release(s.id)
+	r.claims[p.id]++
+	r.ships[s.id] = p.id
+
+	return m, nil
+}
+
+// Check validates the docking of ship "s" on planet "p" while accounting
+// for ports already claimed this turn. No port is claimed. Check is safe to
+// call concurrently so long as no ports are being claimed or released.
+func (r *DockReservations) Check(s Ship, p Planet) (msg.Dock, error) {
+	claimed := r.claims[p.id]
+	if pid, ok := r.ships[s.id]; ok && pid == p.id {
+		claimed--
+	}
+
+	return s.dock(p, claimed)
+}
+
+// Claim claims a port for "m" if it is a dock command, as with Dock. Other
+// commands, and dock commands of ships or planets not on the Board, are
+// ignored.
+func (r *DockReservations) Claim(m CommandMessenger) error {
+	d, ok := m.(msg.Dock)
+	if !ok {
+		return nil
+	}
+
+	s, sok := r.b.ship(d.ID())
+	p, pok := r.b.planet(d.PlanetID())
+	if !sok || !pok {
+		return nil
+	}
+
+	_, err := r.Dock(s, p)
+	return err
+}
+
+// Settle claims ports for the dock commands in "ms" in order. Dock commands
+// for which no port remains are replaced by a NoOp.
+func (r *DockReservations) Settle(ms CommandMessengers) CommandMessengers {
+	out := make(CommandMessengers, len(ms))
+	for k, m := range ms {
+		out[k] = m
+
+		if err := r.Claim(m); err != nil {
+			out[k] = msg.MakeNoOp()
+		}
+	}
+
+	return out
+}
+
+// Release drops any port claimed by the ship with the provided ID.
+func (r *DockReservations) Release(shipID int) {
+	pid, ok := r.ships[shipID]
+	if !ok {
+		return
+	}
+
+	delete(r.ships, shipID)
+	r.claims[pid]--
+}
+
+// Claimed returns the number of ports claimed this turn on the planet with
+// the provided ID.
+func (r *DockReservations) Claimed(planetID int) int {
+	return r.claims[planetID]
+}
@@ -0,0 +1,118 @@
+package ops_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/daved/halitego/ops"
+	"github.com/daved/halitego/ops/opstest"
+)
+
+func TestDockReservations(t *testing.T) {
+	b := opstest.NewBoard(240, 160, 2).
+		Planet(50, 50, 5, opstest.PlanetPorts(2)).
+		Planet(150, 100, 5).
+		Ship(0, 48, 52, opstest.DockedOn(0)).
+		Ship(0, 58, 50).
+		Ship(0, 50, 58).
+		Board()
+
+	p, far := b.Planets()[0], b.Planets()[1]
+	ss := b.Ships()[0]
+	r := ops.NewDockReservations(b)
+
+	if _, err := r.Dock(ss[1], p); err != nil {
+		t.Fatalf("want ship 1 to claim the last port, got %v", err)
+	}
+	if _, err := r.Dock(ss[1], far); !errors.Is(err, ops.ErrNoJuncture) {
+		t.Errorf("want ship 1 unable to reach planet 1, got %v", err)
+	}
+	if r.Claimed(p.ID()) != 1 {
+		t.Errorf("want ship 1 to keep its claim after failing to dock elsewhere, got %d claimed", r.Claimed(p.ID()))
+	}
+	if _, err := r.Check(ss[1], p); err != nil {
+		t.Errorf("want ship 1 to keep its own claim, got %v", err)
+	}
+
+	_, err := r.Dock(ss[2], p)
+	var de *ops.DockingErr
+	if !errors.As(err, &de) || !errors.Is(err, ops.ErrNoPorts) {
+		t.Fatalf("want ship 2 denied the last port, got %v", err)
+	}
+	if de.PortsShort() != 1 || de.NoJuncture() || de.Busy() {
+		t.Errorf("want only 1 port short, got %v", de)
+	}
+	if r.Claimed(p.ID()) != 1 {
+		t.Errorf("want 1 claimed port, got %d", r.Claimed(p.ID()))
+	}
+
+	r.Release(ss[1].ID())
+	if _, err := r.Dock(ss[2], p); err != nil {
+		t.Errorf("want ship 2 to claim the released port, got %v", err)
+	}
+
+	ms := ops.NewDockReservations(b).Settle(ops.CommandMessengers{dock(t, ss[2], p), dock(t, ss[1], p)})
+	if ms[0].Message() != "d 2 0" || ms[1].Message() != "" {
+		t.Errorf("want the first dock settled and the second dropped, got %q and %q", ms[0].Message(), ms[1].Message())
+	}
+}
+
+func TestDockingErr(t *testing.T) {
+	b := opstest.NewBoard(240, 160, 2).
+		Planet(50, 50, 5, opstest.PlanetPorts(2)).
+		Planet(150, 100, 5).
+		Ship(0, 70, 50).
+		Ship(0, 48, 52, opstest.DockedOn(0)).
+		Ship(0, 52, 52, opstest.ShipStatus(ops.Undocking, 0)).
+		Ship(1, 150, 90, opstest.DockedOn(1)).
+		Board()
+
+	ps := b.Planets()
+	ss := b.Ships()[0]
+
+	ds := []struct {
+		s      ops.Ship
+		p      ops.Planet
+		short  int
+		dist   float64 // -1 for any distance
+		busy   bool
+		errors []error
+	}{
+		{ss[0], ps[0], 1, 11, false, []error{ops.ErrNoJuncture, ops.ErrNoPorts}},
+		{ss[1], ps[0], 1, 0, true, []error{ops.ErrNoPorts, ops.ErrNotUndocked}},
+		{ss[2], ps[1], 0, -1, true, []error{ops.ErrNoJuncture, ops.ErrNoRights, ops.ErrNotUndocked}},
+	}
+
+	for _, d := range ds {
+		_, err := d.s.Dock(d.p)
+
+		var de *ops.DockingErr
+		if !errors.As(err, &de) {
+			t.Fatalf("want DockingErr for ship %d on planet %d, got %v", d.s.ID(), d.p.ID(), err)
+		}
+
+		if de.PortsShort() != d.short || de.Busy() != d.busy || (d.dist != 0) != (de.Distance() > 0) {
+			t.Errorf("ship %d on planet %d: got short %d, distance %.2f, busy %v", d.s.ID(), d.p.ID(), de.PortsShort(), de.Distance(), de.Busy())
+		}
+		if d.dist > 0 && de.Distance() != d.dist {
+			t.Errorf("ship %d on planet %d: want distance %v, got %v", d.s.ID(), d.p.ID(), d.dist, de.Distance())
+		}
+
+		for _, want := range d.errors {
+			if !errors.Is(err, want) {
+				t.Errorf("ship %d on planet %d: want %v in %v", d.s.ID(), d.p.ID(), want, err)
+			}
+		}
+	}
+}
+
+func dock(t *testing.T, s ops.Ship, p ops.Planet) ops.CommandMessenger {
+	t.Helper()
+
+	m, err := s.Dock(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return m
+}
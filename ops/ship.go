@@ -1,15 +1,32 @@
 package ops
 
 import (
+	"fmt"
+	"math"
+
 	"github.com/daved/halitego/geom"
 	"github.com/daved/halitego/ops/internal/msg"
 )
 
+// Ship movement and weapon limits.
+const (
+	// MaxThrust is the greatest magnitude a ship may thrust during a turn.
+	MaxThrust = 7
+	// WeaponRadius is the reach of a ship's weapon.
+	WeaponRadius = 5.0
+	// AttackRange is the reach of a ship's weapon after a full thrust, within
+	// which an enemy is able to be attacked during the next turn.
+	AttackRange = MaxThrust + WeaponRadius
+)
+
 // makeShipStatus converts an int to a ShipStatus.
-func makeShipStatus(i int) ShipDockingStatus {
+func makeShipStatus(i int) (ShipDockingStatus, error) {
 	ss := [4]ShipDockingStatus{Undocked, Docking, Docked, Undocking}
+	if i < 0 || i >= len(ss) {
+		return 0, fmt.Errorf("bad docking status %d", i)
+	}
 
-	return ss[i]
+	return ss[i], nil
 }
 
 // Ship represents ship state.
@@ -23,28 +40,38 @@ type Ship struct {
 	cooldown float64
 }
 
-// makeShip from a slice of game state tokens
-func makeShip(playerID int, tokens []string) (Ship, []string) {
-	s := Ship{
-		Entity: Entity{
-			Location: geom.MakeLocation(
-				readTokenFloat(tokens, 1),
-				readTokenFloat(tokens, 2),
-				0.5,
-			),
-			id:     readTokenInt(tokens, 0),
-			health: readTokenFloat(tokens, 3),
-			owner:  playerID,
-		},
-		velX:     readTokenFloat(tokens, 4),
-		velY:     readTokenFloat(tokens, 5),
-		planetID: readTokenInt(tokens, 7),
-		sdStatus: makeShipStatus(readTokenInt(tokens, 6)),
-		docking:  readTokenFloat(tokens, 8),
-		cooldown: readTokenFloat(tokens, 9),
+// makeShip from game state tokens
+func makeShip(playerID int, r *tokenReader) (Ship, error) {
+	var (
+		s      = Ship{Entity: Entity{owner: playerID}}
+		x, y   float64
+		id, st int
+		err    error
+	)
+
+	if id, err = r.int(); err != nil {
+		return s, err
+	}
+	if err = r.floats(&x, &y, &s.health, &s.velX, &s.velY); err != nil {
+		return s, err
+	}
+	if st, err = r.int(); err != nil {
+		return s, err
+	}
+	if s.sdStatus, err = makeShipStatus(st); err != nil {
+		return s, fmt.Errorf("ship %d: %v", id, err)
+	}
+	if s.planetID, err = r.int(); err != nil {
+		return s, err
+	}
+	if err = r.floats(&s.docking, &s.cooldown); err != nil {
+		return s, err
 	}
 
-	return s, tokens[10:]
+	s.id = id
+	s.Location = geom.MakeLocation(x, y, 0.5)
+
+	return s, nil
 }
 
 // DockingStatus ...
@@ -52,6 +79,22 @@ func (s Ship) DockingStatus() ShipDockingStatus {
 	return s.sdStatus
 }
 
+// Velocity returns the current x and y velocity.
+func (s Ship) Velocity() (float64, float64) {
+	return s.velX, s.velY
+}
+
+// PlanetID returns the ID of the planet the ship is docked on. The value is
+// only meaningful when the ship is not undocked.
+func (s Ship) PlanetID() int {
+	return s.planetID
+}
+
+// Cooldown returns the number of turns until the ship's weapon is ready.
+func (s Ship) Cooldown() float64 {
+	return s.cooldown
+}
+
 // NoOp ...
 func (s Ship) NoOp() msg.NoOp {
 	return msg.MakeNoOp()
@@ -59,12 +102,12 @@ func (s Ship) NoOp() msg.NoOp {
 
 // Dock generates a string describing the ship's intension to dock during the current turn
 func (s Ship) Dock(p Planet) (msg.Dock, error) {
+	return s.dock(p, 0)
+}
+
+func (s Ship) dock(p Planet, claimed int) (msg.Dock, error) {
 	msg := msg.MakeDock(s.id, p.id)
-	err := &DockingErr{
-		junct: geom.CenterDistance(p, s)-p.Radius()-4.0 > 0,
-		right: p.owned != 0 && p.Owner() != s.Owner(),
-		ports: p.dockedCt >= p.portCt,
-	}
+	err := makeDockingErr(s, p, claimed)
 
 	if err.IsError() {
 		return msg, err
@@ -74,18 +117,61 @@ func (s Ship) Dock(p Planet) (msg.Dock, error) {
 }
 
 // Undock generates a string describing the ship's intension to undock during the current turn
-func (s Ship) Undock() msg.Undock {
-	return msg.MakeUndock(s.id)
+func (s Ship) Undock() (msg.Undock, error) {
+	msg := msg.MakeUndock(s.id)
+
+	var errs []error
+	switch s.sdStatus {
+	case Undocked:
+		errs = append(errs, ErrNotDocked)
+	case Docking, Undocking:
+		errs = append(errs, ErrDockingTransition)
+	}
+
+	if len(errs) > 0 {
+		return msg, &UndockErr{s: s, errs: errs}
+	}
+
+	return msg, nil
+}
+
+// Thrust generates a string describing the ship's intension to move with the
+// provided magnitude and angle (in degrees) during the current turn. The
+// resulting position must remain within the bounds of the Board.
+func (s Ship) Thrust(b Board, magnitude, angle int) (msg.Thrust, error) {
+	msg := msg.MakeThrust(s.id, magnitude, angle)
+
+	r := float64(angle) * math.Pi / 180
+	sx, sy := s.Coords()
+	x := sx + float64(magnitude)*math.Cos(r)
+	y := sy + float64(magnitude)*math.Sin(r)
+
+	var errs []error
+	if s.sdStatus != Undocked {
+		errs = append(errs, ErrNotUndocked)
+	}
+	if magnitude < 0 || magnitude > MaxThrust {
+		errs = append(errs, ErrExcessThrust)
+	}
+	if x < 0 || y < 0 || x >= float64(b.xLen) || y >= float64(b.yLen) {
+		errs = append(errs, ErrOutOfBounds)
+	}
+
+	if len(errs) > 0 {
+		return msg, &ThrustErr{s: s, mag: magnitude, ang: angle, x: x, y: y, errs: errs}
+	}
+
+	return msg, nil
 }
 
 // Navigate demonstrates how the player might move ships through space
 func (s Ship) Navigate(l geom.Locator) msg.Thrust {
-	sp := 7
+	sp := MaxThrust
 	a := geom.BoundDegrees(l, s)
 
 	d := geom.CenterDistance(l, s)
 	id := s.id
-	if d < 7 {
+	if d < MaxThrust {
 		sp = int(d)
 	}
 
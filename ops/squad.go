@@ -0,0 +1,193 @@
+package ops
+
+import (
+	"sort"
+
+	"github.com/daved/halitego/geom"
+)
+
+// Squad is a group of ships which move together. A Squad is a geom.Marker
+// centered on its centroid and sized to enclose its ships.
+type Squad struct {
+	ss []Ship
+}
+
+// MakeSquad groups the provided ships.
+func MakeSquad(ss ...Ship) Squad {
+	q := Squad{ss: append([]Ship{}, ss...)}
+	sort.Slice(q.ss, func(i, j int) bool {
+		return q.ss[i].id < q.ss[j].id
+	})
+
+	return q
+}
+
+// GroupSquads groups ships which are within "reach" of one another, either
+// directly or by way of other ships. Squads are ordered by their lowest
+// ship ID.
+func GroupSquads(ss []Ship, reach float64) []Squad {
+	ss = MakeSquad(ss...).ss
+
+	root := make([]int, len(ss))
+	for k := range root {
+		root[k] = k
+	}
+
+	var find func(int) int
+	find = func(k int) int {
+		if root[k] != k {
+			root[k] = find(root[k])
+		}
+
+		return root[k]
+	}
+
+	for i := range ss {
+		for j := i + 1; j < len(ss); j++ {
+			if geom.CenterDistance(ss[i], ss[j]) > reach {
+				continue
+			}
+
+			ri, rj := find(i), find(j)
+			if ri > rj {
+				ri, rj = rj, ri
+			}
+			root[rj] = ri
+		}
+	}
+
+	var qs []Squad
+	idx := make(map[int]int)
+	for k, s := range ss {
+		r := find(k)
+
+		n, ok := idx[r]
+		if !ok {
+			n = len(qs)
+			idx[r] = n
+			qs = append(qs, Squad{})
+		}
+
+		qs[n].ss = append(qs[n].ss, s)
+	}
+
+	return qs
+}
+
+// Ships returns the ships of the squad ordered by ID.
+func (q Squad) Ships() []Ship {
+	return q.ss
+}
+
+// Len returns the number of ships in the squad.
+func (q Squad) Len() int {
+	return len(q.ss)
+}
+
+// Has reports whether the ship with ID "id" is in the squad.
+func (q Squad) Has(id int) bool {
+	for _, s := range q.ss {
+		if s.id == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Coords returns the centroid of the squad.
+func (q Squad) Coords() (float64, float64) {
+	if len(q.ss) == 0 {
+		return 0, 0
+	}
+
+	var x, y float64
+	for _, s := range q.ss {
+		sx, sy := s.Coords()
+		x, y = x+sx, y+sy
+	}
+
+	n := float64(len(q.ss))
+
+	return x / n, y / n
+}
+
+// Radius returns the distance from the centroid to the farthest edge of a
+// ship in the squad.
+func (q Squad) Radius() float64 {
+	x, y := q.Coords()
+	c := geom.MakeLocation(x, y, 0)
+
+	var r float64
+	for _, s := range q.ss {
+		if d := geom.CenterDistance(c, s) + s.Radius(); d > r {
+			r = d
+		}
+	}
+
+	return r
+}
+
+// Health returns the total health of the squad's ships.
+func (q Squad) Health() float64 {
+	var h float64
+	for _, s := range q.ss {
+		h += s.health
+	}
+
+	return h
+}
+
+// Merge returns a squad of the ships of both squads.
+func (q Squad) Merge(o Squad) Squad {
+	ss := append([]Ship{}, q.ss...)
+	for _, s := range o.ss {
+		if !q.Has(s.id) {
+			ss = append(ss, s)
+		}
+	}
+
+	return MakeSquad(ss...)
+}
+
+// Split returns a squad of the "n" ships nearest to "l" and a squad of the
+// remaining ships.
+func (q Squad) Split(l geom.Locator, n int) (Squad, Squad) {
+	if n > len(q.ss) {
+		n = len(q.ss)
+	}
+	if n < 0 {
+		n = 0
+	}
+
+	ss := NewQuery(q.ss).OrderBy(func(s Ship) float64 {
+		return geom.CenterDistance(l, s)
+	}).All()
+
+	return MakeSquad(ss[:n]...), MakeSquad(ss[n:]...)
+}
+
+// Navigate moves the squad in formation toward "l". Every ship thrusts with
+// the same heading and magnitude, as measured from the centroid, so that
+// the squad keeps its shape. Ships unable to thrust are sent a no-op.
+func (q Squad) Navigate(b Board, l geom.Locator) CommandMessengers {
+	sp := MaxThrust
+	a := geom.BoundDegrees(l, q)
+
+	if d := geom.CenterDistance(l, q); d < MaxThrust {
+		sp = int(d)
+	}
+
+	ms := make(CommandMessengers, 0, len(q.ss))
+	for _, s := range q.ss {
+		m, err := s.Thrust(b, sp, a)
+		if err != nil {
+			ms = append(ms, s.NoOp())
+			continue
+		}
+
+		ms = append(ms, m)
+	}
+
+	return ms
+}
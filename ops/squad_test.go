@@ -0,0 +1,57 @@
+package ops_test
+
+import (
+	"testing"
+
+	"github.com/daved/halitego/geom"
+	"github.com/daved/halitego/ops"
+	"github.com/daved/halitego/ops/opstest"
+)
+
+func TestSquads(t *testing.T) {
+	b := opstest.NewBoard(240, 160, 1).
+		Ship(0, 10, 10).
+		Ship(0, 14, 10).
+		Ship(0, 18, 10).
+		Ship(0, 100, 100).
+		Board()
+
+	qs := ops.GroupSquads(b.Ships()[0], 5)
+	if len(qs) != 2 || qs[0].Len() != 3 || qs[1].Len() != 1 {
+		t.Fatalf("want squads of 3 and 1 ships, got %v", qs)
+	}
+
+	q := qs[0]
+	if x, y := q.Coords(); x != 14 || y != 10 {
+		t.Errorf("want centroid (14, 10), got (%v, %v)", x, y)
+	}
+	if r := q.Radius(); r != 4.5 {
+		t.Errorf("want radius 4.5, got %v", r)
+	}
+
+	near, rest := q.Split(geom.MakeLocation(20, 10, 0), 1)
+	if near.Len() != 1 || !near.Has(2) || rest.Len() != 2 {
+		t.Errorf("want ship 2 split from ships 0 and 1, got %v and %v", near, rest)
+	}
+	if m := near.Merge(rest).Merge(near); m.Len() != 3 {
+		t.Errorf("want merged squad of 3 ships, got %d", m.Len())
+	}
+
+	ms := q.Navigate(b, geom.MakeLocation(14, 100, 0))
+	want := "t 0 7 90 t 1 7 90 t 2 7 90"
+	if got := message(ms); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func message(ms ops.CommandMessengers) string {
+	s := ""
+	for _, m := range ms {
+		if s != "" {
+			s += " "
+		}
+		s += m.Message()
+	}
+
+	return s
+}
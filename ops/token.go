@@ -0,0 +1,89 @@
+package ops
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// tokenReader reads game state tokens in order.
+type tokenReader struct {
+	ts []string
+	k  int
+}
+
+func newTokenReader(gameData string) *tokenReader {
+	return &tokenReader{ts: strings.Fields(gameData)}
+}
+
+func (r *tokenReader) next() (string, error) {
+	if r.k >= len(r.ts) {
+		return "", fmt.Errorf("token %d: unexpected end of game state", r.k)
+	}
+
+	t := r.ts[r.k]
+	r.k++
+
+	return t, nil
+}
+
+func (r *tokenReader) int() (int, error) {
+	t, err := r.next()
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := strconv.Atoi(t)
+	if err != nil {
+		return 0, fmt.Errorf("token %d: %v", r.k-1, err)
+	}
+
+	return n, nil
+}
+
+// count reads a non-negative int which cannot exceed the number of
+// remaining tokens.
+func (r *tokenReader) count() (int, error) {
+	n, err := r.int()
+	if err != nil {
+		return 0, err
+	}
+
+	if n < 0 || n > r.remaining() {
+		return 0, fmt.Errorf("token %d: bad count %d", r.k-1, n)
+	}
+
+	return n, nil
+}
+
+func (r *tokenReader) float() (float64, error) {
+	t, err := r.next()
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := strconv.ParseFloat(t, 64)
+	if err != nil {
+		return 0, fmt.Errorf("token %d: %v", r.k-1, err)
+	}
+
+	return n, nil
+}
+
+// floats reads a token into each of "fs" in order.
+func (r *tokenReader) floats(fs ...*float64) error {
+	for _, f := range fs {
+		n, err := r.float()
+		if err != nil {
+			return err
+		}
+
+		*f = n
+	}
+
+	return nil
+}
+
+func (r *tokenReader) remaining() int {
+	return len(r.ts) - r.k
+}
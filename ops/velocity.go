@@ -0,0 +1,34 @@
+package ops
+
+// InferVelocities returns a copy of "b" in which the velocity of each ship
+// also on "prev" is its displacement since "prev". The game engine reports
+// every ship's velocity as zero, so a ship's last movement is the best
+// available estimate of its next.
+func InferVelocities(prev, b Board) Board {
+	type coords struct{ x, y float64 }
+
+	last := make(map[int]coords)
+	for _, g := range prev.ss {
+		for _, s := range g {
+			x, y := s.Coords()
+			last[s.id] = coords{x, y}
+		}
+	}
+
+	nb := b
+	nb.ss = make([][]Ship, len(b.ss))
+	for k, g := range b.ss {
+		nb.ss[k] = make([]Ship, len(g))
+
+		for i, s := range g {
+			if c, ok := last[s.id]; ok {
+				x, y := s.Coords()
+				s.velX, s.velY = x-c.x, y-c.y
+			}
+
+			nb.ss[k][i] = s
+		}
+	}
+
+	return nb
+}
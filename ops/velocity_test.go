@@ -0,0 +1,42 @@
+package ops_test
+
+import (
+	"testing"
+
+	"github.com/daved/halitego/ops"
+	"github.com/daved/halitego/ops/opstest"
+)
+
+func TestInferVelocities(t *testing.T) {
+	prev := opstest.NewBoard(240, 160, 2).
+		Ship(0, 10, 10).
+		Ship(1, 100, 100).
+		Board()
+
+	cur := opstest.NewBoard(240, 160, 2).
+		Ship(0, 13, 6).
+		Ship(1, 100, 100).
+		Ship(1, 120, 120, opstest.ShipVelocity(2, 2)).
+		Board()
+
+	b := ops.InferVelocities(prev, cur)
+
+	ds := []struct {
+		s      ops.Ship
+		vx, vy float64
+	}{
+		{b.Ships()[0][0], 3, -4},
+		{b.Ships()[1][0], 0, 0},
+		{b.Ships()[1][1], 2, 2}, // not on the previous board
+	}
+
+	for _, d := range ds {
+		if vx, vy := d.s.Velocity(); vx != d.vx || vy != d.vy {
+			t.Errorf("ship %d: want velocity (%v, %v), got (%v, %v)", d.s.ID(), d.vx, d.vy, vx, vy)
+		}
+	}
+
+	if vx, vy := cur.Ships()[0][0].Velocity(); vx != 0 || vy != 0 {
+		t.Errorf("want current board unmodified, got (%v, %v)", vx, vy)
+	}
+}
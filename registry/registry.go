@@ -0,0 +1,94 @@
+// Package registry holds the bots available to be run by name.
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/daved/halitego/ops"
+)
+
+// Config holds the values made available to bot constructors.
+type Config struct {
+	Logger       ops.Logger
+	InitialBoard ops.Board
+
+	// Seed is the value from which a bot should derive all randomness so
+	// that games are able to be reproduced.
+	Seed int64
+
+	// Workers is the number of ships a bot should evaluate concurrently.
+	// Values below two disable concurrent evaluation.
+	Workers int
+}
+
+// Constructor builds a bot from the provided Config.
+type Constructor func(Config) ops.Commander
+
+type entry struct {
+	name string
+	fn   Constructor
+}
+
+var (
+	mu      sync.RWMutex
+	entries = make(map[string]entry)
+)
+
+// Register makes a bot constructor available by name. Names are matched
+// case-insensitively, and the name as registered is reported to the game
+// engine. Register panics if the name is empty or already registered, or if
+// the constructor is nil.
+func Register(name string, fn Constructor) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if name == "" {
+		panic("registry: bot name is empty")
+	}
+	if fn == nil {
+		panic("registry: constructor is nil for bot " + name)
+	}
+
+	k := strings.ToLower(name)
+	if _, ok := entries[k]; ok {
+		panic("registry: bot registered twice: " + name)
+	}
+
+	entries[k] = entry{name, fn}
+}
+
+// Lookup returns the registered name and constructor of the bot matching
+// the provided name.
+func Lookup(name string) (string, Constructor, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	e, ok := entries[strings.ToLower(name)]
+	if !ok {
+		return "", nil, fmt.Errorf("registry: unknown bot %q (available: %s)", name, strings.Join(names(), ", "))
+	}
+
+	return e.name, e.fn, nil
+}
+
+// Names returns the registered bot names in sorted order.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return names()
+}
+
+func names() []string {
+	var ns []string
+	for _, e := range entries {
+		ns = append(ns, e.name)
+	}
+
+	sort.Strings(ns)
+
+	return ns
+}
@@ -0,0 +1,211 @@
+package strategy
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/daved/halitego/assign"
+	"github.com/daved/halitego/geom"
+	"github.com/daved/halitego/ops"
+)
+
+// DefenseConfig tunes the detection of and response to threats against
+// docked ships.
+type DefenseConfig struct {
+	// Lookahead is the number of turns over which enemy movement is
+	// projected using their velocity, which is inferred from their movement
+	// over the previous turn when commanded by a Commander.
+	Lookahead int
+	// ThreatRange is the distance from a docked ship within which a
+	// projected enemy is a threat.
+	ThreatRange float64
+	// DefendRange is the greatest distance from which a ship is sent to
+	// intercept a threat.
+	DefendRange float64
+	// DefendersPerThreat is the number of ships sent to intercept each
+	// threat.
+	DefendersPerThreat int
+	// UndockRange is the distance from a docked ship within which an
+	// uncontested threat causes the docked ship to undock.
+	UndockRange float64
+}
+
+// DefaultDefenseConfig responds to enemies able to attack within a few
+// turns. Interceptors outnumber threats two to one.
+var DefaultDefenseConfig = DefenseConfig{
+	Lookahead:          3,
+	ThreatRange:        20,
+	DefendRange:        60,
+	DefendersPerThreat: 2,
+	UndockRange:        8,
+}
+
+// Threat is an enemy ship which is projected to come within range of docked
+// ships.
+type Threat struct {
+	Enemy ops.Ship
+	// Docked holds the threatened docked ships.
+	Docked []ops.Ship
+	// Distance is the projected closest approach to a docked ship.
+	Distance float64
+}
+
+func (t Threat) String() string {
+	return fmt.Sprintf("ship %d threatens %d docked ships (closest %.1f)", t.Enemy.ID(), len(t.Docked), t.Distance)
+}
+
+// DefensePlan holds the response to threats against docked ships.
+type DefensePlan struct {
+	Threats []Threat
+	// Intercepts maps defending ship IDs to the enemy they intercept.
+	Intercepts map[int]ops.Ship
+	// Undock holds the IDs of docked ships which should undock.
+	Undock map[int]bool
+}
+
+// DetectThreats returns the enemy ships of player "id" which are projected
+// to come within range of the player's docked ships, nearest first.
+func DetectThreats(b ops.Board, id int, c DefenseConfig) []Threat {
+	docked := ops.NewQuery(b.Ships()[id]).Where(ops.Not(ops.ShipStatus(ops.Undocked))).All()
+	if len(docked) == 0 {
+		return nil
+	}
+
+	var ts []Threat
+	for pid, ss := range b.Ships() {
+		if pid == id {
+			continue
+		}
+
+		for _, e := range ss {
+			if e.DockingStatus() != ops.Undocked {
+				continue
+			}
+
+			t := Threat{Enemy: e, Distance: math.Inf(1)}
+			for _, d := range docked {
+				dist := approach(e, d, c.Lookahead)
+				if dist > c.ThreatRange {
+					continue
+				}
+
+				t.Docked = append(t.Docked, d)
+				t.Distance = math.Min(t.Distance, dist)
+			}
+
+			if len(t.Docked) > 0 {
+				ts = append(ts, t)
+			}
+		}
+	}
+
+	return ops.NewQuery(ts).OrderBy(func(t Threat) float64 {
+		return t.Distance
+	}).All()
+}
+
+// PlanDefense detects threats against the docked ships of player "id",
+// assigns undocked ships to intercept them, and selects docked ships to
+// undock as a last resort when a threat is both close and uncontested. A
+// threat is contested when an interceptor is no farther from the threatened
+// docked ships than the enemy is.
+func PlanDefense(b ops.Board, id int, c DefenseConfig) DefensePlan {
+	p := DefensePlan{
+		Threats:    DetectThreats(b, id, c),
+		Intercepts: make(map[int]ops.Ship),
+		Undock:     make(map[int]bool),
+	}
+
+	if len(p.Threats) == 0 {
+		return p
+	}
+
+	ws := ops.NewQuery(b.Ships()[id]).Where(ops.ShipStatus(ops.Undocked)).All()
+
+	caps := make([]int, len(p.Threats))
+	for k := range caps {
+		caps[k] = c.DefendersPerThreat
+	}
+
+	res := assign.Solve(len(ws), caps, func(w, k int) float64 {
+		d := geom.EdgeDistance(ws[w], p.Threats[k].Enemy)
+		if d > c.DefendRange {
+			return math.Inf(1)
+		}
+
+		return d
+	})
+
+	contested := make([]bool, len(p.Threats))
+	for w, k := range res {
+		if k == assign.Unassigned {
+			continue
+		}
+
+		t := p.Threats[k]
+		p.Intercepts[ws[w].ID()] = t.Enemy
+
+		if nearest(t.Docked, ws[w]) <= nearest(t.Docked, t.Enemy) {
+			contested[k] = true
+		}
+	}
+
+	for k, t := range p.Threats {
+		if contested[k] || t.Distance > c.UndockRange {
+			continue
+		}
+
+		for _, d := range t.Docked {
+			if d.DockingStatus() == ops.Docked && approach(t.Enemy, d, c.Lookahead) <= c.UndockRange {
+				p.Undock[d.ID()] = true
+			}
+		}
+	}
+
+	return p
+}
+
+// maxLeadTurns limits the number of turns by which an intercept leads the
+// enemy.
+const maxLeadTurns = 3.0
+
+// Intercept returns the location at which ship "s" is expected to meet
+// enemy "e", leading the enemy by its velocity.
+func Intercept(s, e ops.Ship) geom.Location {
+	ex, ey := e.Coords()
+	vx, vy := e.Velocity()
+
+	turns := math.Min(geom.CenterDistance(s, e)/ops.MaxThrust, maxLeadTurns)
+
+	return geom.MakeLocation(ex+vx*turns, ey+vy*turns, e.Radius())
+}
+
+// nearest returns the edge distance from "m" to the nearest of "ss".
+func nearest(ss []ops.Ship, m geom.Marker) float64 {
+	d := math.Inf(1)
+	for _, s := range ss {
+		d = math.Min(d, geom.EdgeDistance(s, m))
+	}
+
+	return d
+}
+
+// approach returns the closest distance between the edges of ship "e",
+// projected along its velocity for "turns" turns, and ship "d".
+func approach(e, d ops.Ship, turns int) float64 {
+	ex, ey := e.Coords()
+	vx, vy := e.Velocity()
+	dx, dy := d.Coords()
+
+	px, py := vx*float64(turns), vy*float64(turns)
+
+	// parameter of the point along the projected path nearest to "d"
+	var u float64
+	if l := px*px + py*py; l > 0 {
+		u = math.Max(0, math.Min(1, ((dx-ex)*px+(dy-ey)*py)/l))
+	}
+
+	cx, cy := ex+u*px, ey+u*py
+
+	return math.Hypot(dx-cx, dy-cy) - e.Radius() - d.Radius()
+}
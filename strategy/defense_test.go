@@ -0,0 +1,61 @@
+package strategy
+
+import (
+	"math"
+	"testing"
+
+	"github.com/daved/halitego/ops"
+	"github.com/daved/halitego/ops/opstest"
+)
+
+func TestPlanDefense(t *testing.T) {
+	// an enemy at "x" approaches ship 0, docked on planet 0, from the east
+	contested := func(x float64) ops.Board {
+		return opstest.NewBoard(240, 160, 2).
+			Planet(120, 80, 6).
+			Ship(0, 127, 80, opstest.DockedOn(0)).
+			Ship(0, 130, 86).
+			Ship(1, x, 80).
+			Ship(1, 200, 140).
+			Board()
+	}
+
+	b := ops.InferVelocities(contested(145), contested(138))
+
+	p := PlanDefense(b, 0, DefaultDefenseConfig)
+	if len(p.Threats) != 1 || p.Threats[0].Enemy.ID() != 2 {
+		t.Fatalf("want ship 2 to threaten, got %v", p.Threats)
+	}
+
+	e, ok := p.Intercepts[1]
+	if !ok || e.ID() != 2 {
+		t.Errorf("want ship 1 to intercept ship 2, got %v", p.Intercepts)
+	}
+	if len(p.Undock) != 0 {
+		t.Errorf("want no undocking while contested, got %v", p.Undock)
+	}
+
+	if x, y := Intercept(b.Ships()[0][1], e).Coords(); math.Abs(x-128) > 1e-9 || y != 80 {
+		t.Errorf("want intercept led to (128, 80), got (%.2f, %.2f)", x, y)
+	}
+
+	// a slower enemy at "x" approaches with no ship able to intercept it
+	uncontested := func(x float64) ops.Board {
+		return opstest.NewBoard(240, 160, 2).
+			Planet(120, 80, 6).
+			Ship(0, 127, 80, opstest.DockedOn(0)).
+			Ship(0, 230, 150).
+			Ship(1, x, 80).
+			Board()
+	}
+
+	b = ops.InferVelocities(uncontested(137), uncontested(134))
+
+	p = PlanDefense(b, 0, DefaultDefenseConfig)
+	if len(p.Intercepts) != 0 {
+		t.Errorf("want no interceptor within range, got %v", p.Intercepts)
+	}
+	if !p.Undock[0] {
+		t.Errorf("want ship 0 to undock, got %v", p.Undock)
+	}
+}
@@ -0,0 +1,143 @@
+package strategy
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/daved/halitego/geom"
+	"github.com/daved/halitego/ops"
+)
+
+// PlanetWeights weighs the factors considered when valuing a planet. A
+// planet's production is not weighed: every docked ship produces at the same
+// rate, so the production a planet offers is given by its free ports, and the
+// production reported for a planet is only the progress of its owner toward
+// the next ship.
+type PlanetWeights struct {
+	// Ports is added per free docking port.
+	Ports float64
+	// Resources is added per unit of remaining resources.
+	Resources float64
+	// Distance is subtracted per unit of distance between the planet's
+	// surface and the centroid of the ships being planned for, or of all of
+	// the player's ships when there are none.
+	Distance float64
+	// Safety is added per unit of distance from the nearest enemy ship, up
+	// to SafetyRange.
+	Safety      float64
+	SafetyRange float64
+	// Contention is subtracted per enemy ship within ContentionRange of
+	// the planet's surface.
+	Contention      float64
+	ContentionRange float64
+}
+
+// DefaultPlanetWeights favor large, nearby planets away from the enemy.
+var DefaultPlanetWeights = PlanetWeights{
+	Ports:           10,
+	Resources:       0.002,
+	Distance:        0.25,
+	Safety:          0.1,
+	SafetyRange:     60,
+	Contention:      8,
+	ContentionRange: 20,
+}
+
+// PlanetValue is the valuation of a planet within an expansion plan.
+type PlanetValue struct {
+	Planet ops.Planet
+	Score  float64
+	// Ships is the number of ships the plan sends to the planet.
+	Ships int
+
+	Distance   float64
+	Safety     float64
+	Contenders int
+}
+
+func (v PlanetValue) String() string {
+	return fmt.Sprintf("planet %d: score %.1f, %d ships (ports %d, distance %.1f, safety %.1f, contenders %d)",
+		v.Planet.ID(), v.Score, v.Ships, v.Planet.FreePorts(), v.Distance, v.Safety, v.Contenders)
+}
+
+// ExpansionPlan is an ordering of planets from most to least valuable.
+type ExpansionPlan []PlanetValue
+
+func (p ExpansionPlan) String() string {
+	vs := make([]string, len(p))
+	for k, v := range p {
+		vs[k] = v.String()
+	}
+
+	return strings.Join(vs, "; ")
+}
+
+// PlanExpansion values the planets on which player "id" is able to dock,
+// orders them from most to least valuable, and allots ships "ss" to them in
+// order, up to the free ports of each planet. "ss" should hold only the
+// undocked ships free to expand.
+func PlanExpansion(b ops.Board, id int, ss []ops.Ship, w PlanetWeights) ExpansionPlan {
+	home := ops.MakeSquad(ss...)
+	if home.Len() == 0 {
+		home = ops.MakeSquad(b.Ships()[id]...)
+	}
+
+	var enemies []ops.Ship
+	for pid, es := range b.Ships() {
+		if pid != id {
+			enemies = append(enemies, es...)
+		}
+	}
+
+	ps := ops.QueryPlanets(b).Where(
+		func(p ops.Planet) bool { return !p.Owned() || p.Owner() == id },
+		ops.PlanetFreePorts(1),
+	).All()
+
+	plan := make(ExpansionPlan, 0, len(ps))
+	for _, p := range ps {
+		v := PlanetValue{
+			Planet: p,
+			Safety: w.SafetyRange,
+		}
+
+		if home.Len() > 0 {
+			v.Distance = geom.CenterDistance(p, home) - p.Radius()
+		}
+
+		for _, e := range enemies {
+			d := geom.EdgeDistance(p, e)
+			v.Safety = math.Min(v.Safety, d)
+
+			if d <= w.ContentionRange {
+				v.Contenders++
+			}
+		}
+
+		v.Score = w.Ports*float64(p.FreePorts()) +
+			w.Resources*p.Resources() -
+			w.Distance*math.Max(v.Distance, 0) +
+			w.Safety*math.Max(v.Safety, 0) -
+			w.Contention*float64(v.Contenders)
+
+		plan = append(plan, v)
+	}
+
+	plan = ops.NewQuery(plan).OrderBy(ops.Desc(func(v PlanetValue) float64 {
+		return v.Score
+	})).All()
+
+	avail := len(ss)
+	for k := range plan {
+		n := plan[k].Planet.FreePorts()
+		if n > avail {
+			n = avail
+		}
+
+		plan[k].Ships = n
+		avail -= n
+	}
+
+	return plan
+}
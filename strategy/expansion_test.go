@@ -0,0 +1,66 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/daved/halitego/ops"
+	"github.com/daved/halitego/ops/opstest"
+)
+
+func TestPlanExpansion(t *testing.T) {
+	b := opstest.NewBoard(240, 160, 2).
+		Planet(60, 80, 4, opstest.PlanetPorts(1)).
+		Planet(40, 40, 8, opstest.PlanetPorts(4)).
+		Planet(200, 80, 8, opstest.PlanetPorts(4), opstest.PlanetOwner(1)).
+		Ship(0, 30, 80).
+		Ship(0, 30, 82).
+		Ship(0, 30, 78).
+		Ship(0, 28, 80, opstest.DockedOn(1)).
+		Ship(1, 70, 80).
+		Ship(1, 72, 80).
+		Board()
+
+	undocked := ops.NewQuery(b.Ships()[0]).Where(ops.ShipStatus(ops.Undocked)).All()
+
+	plan := PlanExpansion(b, 0, undocked, DefaultPlanetWeights)
+	if len(plan) != 2 {
+		t.Fatalf("want 2 planets able to be docked, got %s", plan)
+	}
+
+	if got := plan[0].Planet.ID(); got != 1 {
+		t.Errorf("want planet 1 first, got %s", plan)
+	}
+	if got := plan[1].Contenders; got != 2 {
+		t.Errorf("want 2 contenders of planet 0, got %d", got)
+	}
+
+	if plan[0].Ships != 3 || plan[1].Ships != 0 {
+		t.Errorf("want all 3 undocked ships sent to planet 1, got %s", plan)
+	}
+
+	plan = PlanExpansion(b, 0, undocked[:1], DefaultPlanetWeights)
+	if plan[0].Ships != 1 || plan[1].Ships != 0 {
+		t.Errorf("want only the 1 available ship allotted, got %s", plan)
+	}
+}
+
+func TestPlanExpansionDistance(t *testing.T) {
+	b := opstest.NewBoard(240, 160, 2).
+		Planet(120, 100, 5).
+		Planet(120, 20, 5).
+		Planet(10, 140, 4, opstest.PlanetPorts(1)).
+		Ship(0, 20, 80).
+		Ship(0, 220, 80).
+		Ship(0, 10, 146, opstest.DockedOn(2)).
+		Ship(1, 235, 155).
+		Board()
+
+	plan := PlanExpansion(b, 0, b.Ships()[0][:2], DefaultPlanetWeights)
+	if len(plan) != 2 || plan[0].Planet.ID() != 0 {
+		t.Fatalf("want planet 0 ahead of planet 1, got %s", plan)
+	}
+
+	if plan[0].Distance != 15 || plan[1].Distance != 55 {
+		t.Errorf("want distances 15 and 55 from the undocked ships' centroid, got %s", plan)
+	}
+}
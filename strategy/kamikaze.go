@@ -0,0 +1,193 @@
+package strategy
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/daved/halitego/geom"
+	"github.com/daved/halitego/ops"
+)
+
+// RamConfig tunes the selection of kamikaze targets.
+type RamConfig struct {
+	// Reach is the greatest edge distance between a ship and its target.
+	Reach float64
+	// MinRatio is the least ratio of enemy health destroyed to own health
+	// lost for which a ram is made.
+	MinRatio float64
+}
+
+// DefaultRamConfig only trades ships for at least twice their worth.
+var DefaultRamConfig = RamConfig{
+	Reach:    35,
+	MinRatio: 2,
+}
+
+// Ram is a ship's collision course with an enemy planet or ship.
+type Ram struct {
+	Target geom.Marker
+	// PlanetID is the ID of the target planet, or -1 if the target is a
+	// ship.
+	PlanetID int
+	// Gain is the enemy health destroyed and Loss is the health of our
+	// own ships lost, including every ship ramming the target.
+	Gain float64
+	Loss float64
+}
+
+func (r Ram) String() string {
+	kind, id := "planet", r.PlanetID
+	if r.PlanetID < 0 {
+		kind = "ship"
+		if s, ok := r.Target.(ops.Ship); ok {
+			id = s.ID()
+		}
+	}
+
+	return fmt.Sprintf("ram %s %d (gain %.0f, loss %.0f)", kind, id, r.Gain, r.Loss)
+}
+
+func (r Ram) favorable(ratio float64) bool {
+	return r.Gain > 0 && r.Gain >= ratio*r.Loss
+}
+
+// PlanRams selects favorable rams for ships "ss" of player "id", keyed by
+// ship ID. An enemy planet is rammed by the nearest ships able to destroy
+// it, given the damage already headed its way, so that its explosion
+// damages nearby enemies. Planets which are about to explode regardless are
+// left alone. Remaining ships may ram an enemy ship of far greater health.
+//
+// Colliding ships are both destroyed, whatever their health, so ramming a
+// ship gains the target's health at the cost of the rammer's own. A ship
+// ramming a planet deals damage equal to its health.
+func PlanRams(b ops.Board, id int, ss []ops.Ship, c RamConfig) map[int]Ram {
+	rams := make(map[int]Ram)
+
+	avail := func(s ops.Ship) bool {
+		_, ok := rams[s.ID()]
+		return !ok
+	}
+
+	type planetRam struct {
+		ram     Ram
+		rammers []ops.Ship
+	}
+
+	plan := func(p ops.Planet) (planetRam, bool) {
+		need := p.Health() - p.RamDamage(b)
+		if need <= 0 {
+			return planetRam{}, false
+		}
+
+		cs := p.Casualties(b)
+		r := planetRam{ram: Ram{Target: p, PlanetID: p.ID(), Loss: cs[id]}}
+		for pid, dmg := range cs {
+			if pid != id {
+				r.ram.Gain += dmg
+			}
+		}
+
+		near := ops.NewQuery(ss).Where(avail, func(s ops.Ship) bool {
+			return geom.EdgeDistance(p, s) <= c.Reach
+		}).OrderBy(ops.ShipEdgeDistance(p)).All()
+
+		for _, s := range near {
+			if need <= 0 {
+				break
+			}
+
+			need -= s.Health()
+			r.rammers = append(r.rammers, s)
+			r.ram.Loss += s.Health() - math.Min(p.BlastDamage(s), s.Health())
+		}
+
+		return r, need <= 0 && r.ram.favorable(c.MinRatio)
+	}
+
+	var cands []planetRam
+	for _, p := range b.Planets() {
+		if !p.Owned() || p.Owner() == id {
+			continue
+		}
+
+		if r, ok := plan(p); ok {
+			cands = append(cands, r)
+		}
+	}
+
+	cands = ops.NewQuery(cands).OrderBy(ops.Desc(func(r planetRam) float64 {
+		return r.ram.Gain / math.Max(r.ram.Loss, 1)
+	})).All()
+
+	for _, cand := range cands {
+		r, ok := plan(cand.ram.Target.(ops.Planet))
+		if !ok {
+			continue
+		}
+
+		for _, s := range r.rammers {
+			rams[s.ID()] = r.ram
+		}
+	}
+
+	rammed := make(map[int]bool)
+	for _, s := range ops.NewQuery(ss).Where(avail).All() {
+		var best Ram
+		for pid, es := range b.Ships() {
+			if pid == id {
+				continue
+			}
+
+			for _, e := range es {
+				if rammed[e.ID()] || geom.EdgeDistance(e, s) > c.Reach {
+					continue
+				}
+
+				r := Ram{Target: e, PlanetID: -1, Gain: e.Health(), Loss: s.Health()}
+				if r.favorable(c.MinRatio) && r.Gain > best.Gain {
+					best = r
+				}
+			}
+		}
+
+		if best.Target != nil {
+			rams[s.ID()] = best
+			rammed[best.Target.(ops.Ship).ID()] = true
+		}
+	}
+
+	return rams
+}
+
+// Doomed returns the planets projected to explode: those which ships
+// already on collision courses will destroy during the next turn, and those
+// targeted by rams "rams".
+func Doomed(b ops.Board, rams map[int]Ram) []ops.Planet {
+	ps := ops.ImminentExplosions(b)
+
+	ids := make(map[int]bool)
+	for _, p := range ps {
+		ids[p.ID()] = true
+	}
+
+	for _, r := range rams {
+		if p, ok := r.Target.(ops.Planet); ok && !ids[p.ID()] {
+			ids[p.ID()] = true
+			ps = append(ps, p)
+		}
+	}
+
+	return ops.NewQuery(ps).OrderBy(func(p ops.Planet) float64 {
+		return float64(p.ID())
+	}).All()
+}
+
+// RamCourse returns the location ship "s" steers toward to carry out ram
+// "r", leading moving targets.
+func RamCourse(s ops.Ship, r Ram) geom.Marker {
+	if e, ok := r.Target.(ops.Ship); ok {
+		return Intercept(s, e)
+	}
+
+	return r.Target
+}
@@ -0,0 +1,49 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/daved/halitego/ops/opstest"
+)
+
+func TestPlanRams(t *testing.T) {
+	b := opstest.NewBoard(240, 160, 2).
+		Planet(160, 40, 5, opstest.PlanetHealth(200)).
+		Planet(60, 120, 5, opstest.PlanetHealth(50)).
+		Ship(0, 140, 40).
+		Ship(0, 140, 45).
+		Ship(0, 45, 120, opstest.ShipHealth(60)).
+		Ship(0, 20, 20, opstest.ShipHealth(200)).
+		Ship(1, 166, 40, opstest.DockedOn(0)).
+		Ship(1, 165, 44, opstest.DockedOn(0)).
+		Ship(1, 170, 37).
+		Ship(1, 54, 120).
+		Ship(1, 66, 120).
+		Ship(1, 30, 20).
+		Board()
+
+	rams := PlanRams(b, 0, b.Ships()[0], DefaultRamConfig)
+
+	if r, ok := rams[0]; !ok || r.PlanetID != 0 {
+		t.Errorf("want ship 0 to ram planet 0, got %v", rams)
+	}
+
+	if _, ok := rams[1]; ok {
+		t.Errorf("want ship 1 held back once planet 0 is destroyed, got %v", rams)
+	}
+
+	// the unowned planet 1 is left alone, though its explosion would be
+	// favorable, and the weak ship 2 trades itself for a healthy ship
+	if r, ok := rams[2]; !ok || r.PlanetID != -1 || RamCourse(b.Ships()[0][2], r) == nil {
+		t.Errorf("want ship 2 to ram ship 7, got %v", rams)
+	}
+
+	if _, ok := rams[3]; ok {
+		t.Errorf("want ship 3 not to trade 200 health for 255, got %v", rams)
+	}
+
+	ps := Doomed(b, rams)
+	if len(ps) != 1 || ps[0].ID() != 0 {
+		t.Errorf("want planet 0 doomed by the planned ram, got %v", ps)
+	}
+}
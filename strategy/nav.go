@@ -0,0 +1,81 @@
+package strategy
+
+import (
+	"math/rand"
+
+	"github.com/daved/halitego/geom"
+	"github.com/daved/halitego/ops"
+)
+
+// maxNavTrials is the number of detours attempted before a ship gives up on
+// reaching its target.
+const maxNavTrials = 256
+
+// Navigate demonstrates how the player might negotiate obstacles between
+// a ship and its target
+func Navigate(rng *rand.Rand, obstacles []geom.Marker, target geom.Marker, s ops.Ship) ops.CommandMessenger {
+	wp, ok := waypoint(0, rng, obstacles, target, s)
+	if !ok {
+		return s.NoOp()
+	}
+
+	return s.Navigate(wp)
+}
+
+// NavigateSquad moves squad "q" in formation toward its target while
+// negotiating obstacles. Obstacles which are ships of the squad are
+// ignored.
+func NavigateSquad(rng *rand.Rand, b ops.Board, obstacles []geom.Marker, target geom.Marker, q ops.Squad) ops.CommandMessengers {
+	var ms []geom.Marker
+	for _, m := range obstacles {
+		if !member(q, m) {
+			ms = append(ms, m)
+		}
+	}
+
+	wp, ok := waypoint(0, rng, ms, target, q)
+	if !ok {
+		var nos ops.CommandMessengers
+		for _, s := range q.Ships() {
+			nos = append(nos, s.NoOp())
+		}
+
+		return nos
+	}
+
+	return q.Navigate(b, wp)
+}
+
+// waypoint finds a location toward the target which "m" is able to reach
+// unobstructed, detouring around obstacles as needed.
+func waypoint(trial int, rng *rand.Rand, ms []geom.Marker, target, m geom.Marker) (geom.Marker, bool) {
+	trial++
+	if trial > maxNavTrials {
+		return nil, false
+	}
+
+	ob := geom.Obstacles(ms, target, m)
+	if !ob {
+		return target, true
+	}
+
+	buf := float64(rng.Intn(24) + 24)
+	dir := geom.Left
+	if rng.Intn(2) == 0 {
+		dir = geom.Right
+	}
+
+	pl := geom.PerpindicularLocation(buf, dir, target, m)
+	return waypoint(trial, rng, ms, pl, m)
+}
+
+func member(q ops.Squad, m geom.Marker) bool {
+	x, y := m.Coords()
+	for _, s := range q.Ships() {
+		if sx, sy := s.Coords(); sx == x && sy == y {
+			return true
+		}
+	}
+
+	return false
+}
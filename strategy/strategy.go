@@ -0,0 +1,286 @@
+// Package strategy provides a framework for building bots from composable
+// per-ship behaviors.
+package strategy
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/daved/halitego/ops"
+)
+
+// Behavior decides how a single ship should act during a turn. Behaviors
+// which do not apply to the ship return false so that the next behavior in
+// priority order is consulted.
+type Behavior interface {
+	Name() string
+	Behave(t *Turn, s ops.Ship) (ops.CommandMessenger, bool)
+}
+
+type behaviorFunc struct {
+	name string
+	fn   func(*Turn, ops.Ship) (ops.CommandMessenger, bool)
+}
+
+// BehaviorFunc adapts a function into a Behavior with the provided name.
+func BehaviorFunc(name string, fn func(*Turn, ops.Ship) (ops.CommandMessenger, bool)) Behavior {
+	return behaviorFunc{name, fn}
+}
+
+// Name ...
+func (b behaviorFunc) Name() string {
+	return b.name
+}
+
+// Behave ...
+func (b behaviorFunc) Behave(t *Turn, s ops.Ship) (ops.CommandMessenger, bool) {
+	return b.fn(t, s)
+}
+
+// Role identifies the set of behaviors applied to a ship.
+type Role string
+
+// DefaultRole is given to ships when no RoleAssigner is set, or when the
+// RoleAssigner returns an empty Role.
+const DefaultRole Role = "default"
+
+// RoleAssigner decides the Role of a ship for the current turn.
+type RoleAssigner func(t *Turn, s ops.Ship) Role
+
+// Preparer is called once per turn before any ship is evaluated. It is the
+// place to compute state shared by all ships.
+type Preparer func(t *Turn)
+
+// DecisionLogger describes loggers able to record the command chosen for
+// each ship along with the reason it was chosen. Decisions are only
+// formatted when DecisionsEnabled returns true.
+type DecisionLogger interface {
+	DecisionsEnabled() bool
+	Decision(shipID int, decision, reason string)
+}
+
+// Option configures a Commander.
+type Option func(*Commander)
+
+// WithRole sets the behaviors of a Role in priority order. The first
+// behavior which applies to a ship decides its command.
+func WithRole(r Role, bs ...Behavior) Option {
+	return func(c *Commander) {
+		c.roles[r] = bs
+	}
+}
+
+// WithRoleAssigner sets the function used to decide the Role of each ship.
+func WithRoleAssigner(fn RoleAssigner) Option {
+	return func(c *Commander) {
+		c.assign = fn
+	}
+}
+
+// WithPreparer adds a function to be called at the start of each turn.
+func WithPreparer(fn Preparer) Option {
+	return func(c *Commander) {
+		c.preps = append(c.preps, fn)
+	}
+}
+
+// WithRand sets the source of randomness exposed to behaviors.
+func WithRand(rng *rand.Rand) Option {
+	return func(c *Commander) {
+		c.rng = rng
+	}
+}
+
+// WithSeed sets the source of randomness exposed to behaviors to one seeded
+// with the provided value. Commanders are seeded with zero by default so
+// that games are reproducible.
+func WithSeed(seed int64) Option {
+	return WithRand(rand.New(rand.NewSource(seed)))
+}
+
+// WithWorkers sets the number of ships evaluated concurrently. When greater
+// than one, dock commands are settled in board order once all ships are
+// evaluated, and ships whose evaluation depended on ports claimed by earlier
+// ships are evaluated again. Commands do not depend on the number of workers.
+// Behaviors must not modify state shared between ships.
+func WithWorkers(n int) Option {
+	return func(c *Commander) {
+		c.workers = n
+	}
+}
+
+// Commander runs behaviors for each ship and satisfies ops.Commander.
+type Commander struct {
+	l       ops.Logger
+	rng     *rand.Rand
+	workers int
+	turn    int
+	preps   []Preparer
+	assign  RoleAssigner
+	roles   map[Role][]Behavior
+	prev    *ops.Board
+}
+
+// New ...
+func New(l ops.Logger, opts ...Option) *Commander {
+	c := &Commander{
+		l:     l,
+		rng:   rand.New(rand.NewSource(0)),
+		roles: make(map[Role][]Behavior),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Command ...
+//
+// The engine reports no ship velocities, so the velocity of each ship on the
+// Board given to behaviors is its displacement since the previous turn.
+func (c *Commander) Command(b ops.Board, id int) ops.CommandMessengers {
+	c.turn++
+
+	raw := b
+	if c.prev != nil {
+		b = ops.InferVelocities(*c.prev, b)
+	}
+	c.prev = &raw
+
+	t := &Turn{
+		Board:        b,
+		ID:           id,
+		Num:          c.turn,
+		Rand:         c.rng,
+		Log:          c.l,
+		Reservations: ops.NewDockReservations(b),
+	}
+
+	for _, p := range c.preps {
+		p(t)
+	}
+
+	seed := c.rng.Int63()
+
+	if c.workers > 1 {
+		return c.commandConcurrently(t, seed)
+	}
+
+	var ms ops.CommandMessengers
+	for _, s := range t.Ships() {
+		m, reason := c.command(shipTurn(t, seed, s), s)
+		c.decided(s, m, reason)
+
+		ms = append(ms, m)
+	}
+
+	return ms
+}
+
+// commandConcurrently evaluates ships using a pool of workers, checking
+// docks without claiming ports. Ports are then claimed in board order. A
+// ship which checked a planet on which an earlier ship has since claimed a
+// port is evaluated again, as it would have been had the ships been
+// evaluated in turn.
+func (c *Commander) commandConcurrently(t *Turn, seed int64) ops.CommandMessengers {
+	ss := t.Ships()
+
+	var mu sync.Mutex
+	reasons := make(map[int]string)
+	checked := make(map[int][]int)
+
+	ms := ops.CommandShips(ss, c.workers, func(s ops.Ship) ops.CommandMessenger {
+		st := shipTurn(t, seed, s)
+		st.settle = true
+
+		m, reason := c.command(st, s)
+
+		mu.Lock()
+		reasons[s.ID()] = reason
+		checked[s.ID()] = st.checked
+		mu.Unlock()
+
+		return m
+	})
+
+	for k, s := range ss {
+		if !claimed(t.Reservations, checked[s.ID()]) && t.Reservations.Claim(ms[k]) == nil {
+			continue
+		}
+
+		m, reason := c.command(shipTurn(t, seed, s), s)
+		ms[k], reasons[s.ID()] = m, reason+" (reevaluated after settling)"
+	}
+
+	for k, s := range ss {
+		c.decided(s, ms[k], reasons[s.ID()])
+	}
+
+	return ms
+}
+
+// claimed reports whether a port has been claimed on any of the planets
+// with the provided IDs.
+func claimed(r *ops.DockReservations, planetIDs []int) bool {
+	for _, id := range planetIDs {
+		if r.Claimed(id) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// shipSeedMul spreads ship IDs across the seed space when deriving the
+// per-ship sources of randomness.
+const shipSeedMul = 0x9E3779B97F4A7C15 >> 1
+
+// shipTurn returns a copy of "t" whose source of randomness is derived from
+// "seed" and the ID of ship "s", so that the choices made for a ship do not
+// depend on the order in which ships are evaluated.
+func shipTurn(t *Turn, seed int64, s ops.Ship) *Turn {
+	st := *t
+	st.Rand = rand.New(rand.NewSource(seed ^ int64(s.ID())*shipSeedMul))
+
+	return &st
+}
+
+func (c *Commander) command(t *Turn, s ops.Ship) (ops.CommandMessenger, string) {
+	r := c.role(t, s)
+
+	for _, bh := range c.roles[r] {
+		if m, ok := bh.Behave(t, s); ok {
+			return m, string(r) + "/" + bh.Name()
+		}
+	}
+
+	return s.NoOp(), string(r) + "/none"
+}
+
+func (c *Commander) decided(s ops.Ship, m ops.CommandMessenger, reason string) {
+	dl, ok := c.l.(DecisionLogger)
+	if !ok || !dl.DecisionsEnabled() {
+		return
+	}
+
+	d := m.Message()
+	if d == "" {
+		d = "noop"
+	}
+
+	dl.Decision(s.ID(), d, reason)
+}
+
+func (c *Commander) role(t *Turn, s ops.Ship) Role {
+	if c.assign == nil {
+		return DefaultRole
+	}
+
+	if r := c.assign(t, s); r != "" {
+		return r
+	}
+
+	return DefaultRole
+}
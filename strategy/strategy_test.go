@@ -0,0 +1,150 @@
+package strategy
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/daved/halitego/geom"
+	"github.com/daved/halitego/ops"
+	"github.com/daved/halitego/ops/opstest"
+)
+
+type countingMessenger struct {
+	ct *int
+}
+
+func (m countingMessenger) Message() string {
+	*m.ct++
+	return ""
+}
+
+type decisionLogger struct {
+	enabled bool
+	reasons []string
+}
+
+func (l *decisionLogger) Printf(string, ...interface{}) {}
+
+func (l *decisionLogger) DecisionsEnabled() bool {
+	return l.enabled
+}
+
+func (l *decisionLogger) Decision(shipID int, decision, reason string) {
+	l.reasons = append(l.reasons, reason)
+}
+
+func TestCommander(t *testing.T) {
+	b := opstest.NewBoard(240, 160, 1).
+		Ship(0, 10, 10).
+		Ship(0, 20, 10).
+		Ship(0, 30, 10).
+		Board()
+
+	var prepared, called []string
+	behavior := func(name string, ok bool) Behavior {
+		return BehaviorFunc(name, func(_ *Turn, s ops.Ship) (ops.CommandMessenger, bool) {
+			if len(prepared) != 1 {
+				t.Errorf("want turn prepared before commanding ship %d", s.ID())
+			}
+
+			called = append(called, fmt.Sprintf("%d:%s", s.ID(), name))
+			return s.Navigate(geom.MakeLocation(100, 100, 0)), ok
+		})
+	}
+
+	l := &decisionLogger{enabled: true}
+	c := New(l,
+		WithPreparer(func(t *Turn) {
+			prepared = append(prepared, fmt.Sprintf("turn %d", t.Num))
+		}),
+		WithRoleAssigner(func(t *Turn, s ops.Ship) Role {
+			return map[int]Role{0: "a", 1: "", 2: "b"}[s.ID()]
+		}),
+		WithRole("a", behavior("skip", false), behavior("act", true), behavior("unreached", true)),
+		WithRole("b"),
+		WithRole(DefaultRole, behavior("default", true)),
+	)
+
+	ms := c.Command(b, 0)
+
+	if len(prepared) != 1 {
+		t.Errorf("want 1 preparation, got %v", prepared)
+	}
+
+	want := []string{"0:skip", "0:act", "1:default"}
+	if strings.Join(called, " ") != strings.Join(want, " ") {
+		t.Errorf("want behaviors called %v, got %v", want, called)
+	}
+
+	want = []string{"a/act", "default/default", "b/none"}
+	if strings.Join(l.reasons, " ") != strings.Join(want, " ") {
+		t.Errorf("want reasons %v, got %v", want, l.reasons)
+	}
+
+	if len(ms) != 3 || ms[0].Message() == "" || ms[1].Message() == "" || ms[2].Message() != "" {
+		t.Errorf("want ships 0 and 1 commanded and a NoOp for ship 2, got %v", ms)
+	}
+}
+
+func TestDecisions(t *testing.T) {
+	b := opstest.NewBoard(240, 160, 1).
+		Ship(0, 10, 10).
+		Ship(0, 20, 10).
+		Board()
+
+	for _, enabled := range []bool{false, true} {
+		var ct int
+		l := &decisionLogger{enabled: enabled}
+
+		c := New(l, WithRole(DefaultRole, BehaviorFunc("count", func(t *Turn, s ops.Ship) (ops.CommandMessenger, bool) {
+			return countingMessenger{&ct}, true
+		})))
+		c.Command(b, 0)
+
+		want := 0
+		if enabled {
+			want = 2
+		}
+
+		if ct != want || len(l.reasons) != want {
+			t.Errorf("enabled %v: want %d messages formatted and logged, got %d and %d", enabled, want, ct, len(l.reasons))
+		}
+		if enabled && l.reasons[0] != "default/count" {
+			t.Errorf("want reason default/count, got %q", l.reasons[0])
+		}
+	}
+}
+
+func TestWorkerPanic(t *testing.T) {
+	bb := opstest.NewBoard(240, 160, 1).
+		Ship(0, 10, 20).
+		Ship(0, 10, 30).
+		Ship(0, 10, 40).
+		Ship(0, 10, 50)
+
+	x, y := bb.Dimensions()
+	in := fmt.Sprintf("0\n%d %d\n%s\n", x, y, bb.Line()) + strings.Repeat(bb.Line()+"\n", 3)
+
+	c := New(&decisionLogger{}, WithWorkers(4), WithRole(DefaultRole, BehaviorFunc("panic", func(t *Turn, s ops.Ship) (ops.CommandMessenger, bool) {
+		if t.Num == 2 && s.ID() == 2 {
+			panic("ship 2")
+		}
+
+		return s.Navigate(geom.MakeLocation(120, 80, 0)), true
+	})))
+
+	var out bytes.Buffer
+	o := ops.New("panicky",
+		ops.WithInput(strings.NewReader(in)),
+		ops.WithOutput(&out),
+		ops.WithFailurePolicy(ops.MaxConsecutiveFailures(2)),
+	)
+	o.Run(&decisionLogger{}, c)
+
+	got := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")[1:]
+	if len(got) != 3 || got[0] == "" || got[1] != "" || got[2] != got[0] {
+		t.Errorf("want the panicking turn skipped and the others commanded, got %q", got)
+	}
+}
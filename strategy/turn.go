@@ -0,0 +1,54 @@
+package strategy
+
+import (
+	"math/rand"
+
+	"github.com/daved/halitego/geom"
+	"github.com/daved/halitego/ops"
+)
+
+// Turn holds the state available to behaviors during a single turn.
+type Turn struct {
+	Board        ops.Board
+	ID           int
+	Num          int
+	Rand         *rand.Rand
+	Log          ops.Logger
+	Reservations *ops.DockReservations
+
+	// settle defers port claims to the Commander so that ships are able to
+	// be evaluated concurrently. The planets checked for docking are noted in
+	// checked so that the Commander is able to tell whether the ship's
+	// evaluation was affected by the ports claimed by other ships.
+	settle  bool
+	checked []int
+}
+
+// Ships returns the ships owned by the commanded player.
+func (t *Turn) Ships() []ops.Ship {
+	return t.Board.Ships()[t.ID]
+}
+
+// Dock validates the docking of ship "s" on planet "p" while accounting for
+// the ports claimed by other ships this turn. Behaviors should consult the
+// reservations only through Dock.
+func (t *Turn) Dock(s ops.Ship, p ops.Planet) (ops.CommandMessenger, error) {
+	if t.settle {
+		t.checked = append(t.checked, p.ID())
+		return t.Reservations.Check(s, p)
+	}
+
+	return t.Reservations.Dock(s, p)
+}
+
+// Navigate returns a thrust which moves ship "s" toward the target while
+// avoiding the provided obstacles.
+func (t *Turn) Navigate(obstacles []geom.Marker, target geom.Marker, s ops.Ship) ops.CommandMessenger {
+	return Navigate(t.Rand, obstacles, target, s)
+}
+
+// NavigateSquad returns thrusts which move squad "q" in formation toward
+// the target while avoiding the provided obstacles.
+func (t *Turn) NavigateSquad(obstacles []geom.Marker, target geom.Marker, q ops.Squad) ops.CommandMessengers {
+	return NavigateSquad(t.Rand, t.Board, obstacles, target, q)
+}